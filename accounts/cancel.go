@@ -0,0 +1,125 @@
+package accounts
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ess/maury/client"
+)
+
+// Canceller provides an interface for the cancellation functions to talk to
+// the API
+type Canceller interface {
+	Reader
+	Post(string, url.Values, []byte) ([]byte, error)
+}
+
+// LiveEnvironmentsError is returned when the API refuses to cancel an account
+// because it still has running environments (409 Conflict)
+type LiveEnvironmentsError struct {
+	Account *Entity
+	Reason  string
+}
+
+func (err *LiveEnvironmentsError) Error() string {
+	return "Account " + err.Account.ID + " has live environments: " + err.Reason
+}
+
+// BillingError is returned when the API refuses to change the status of an
+// account due to a billing problem (402 Payment Required)
+type BillingError struct {
+	Account *Entity
+	Reason  string
+}
+
+func (err *BillingError) Error() string {
+	return "Account " + err.Account.ID + " has a billing problem: " + err.Reason
+}
+
+// Cancel requests that the given account be cancelled for the given reason.
+// If there are issues along the way, a non-nil error is returned. If the
+// account still has running environments, the error is a
+// *LiveEnvironmentsError, and if there is a billing problem, the error is a
+// *BillingError. Otherwise, the error is nil and the refreshed entity is
+// returned.
+func Cancel(driver Canceller, account *Entity, reason string) (*Entity, error) {
+	wrapped := struct {
+		Cancellation map[string]string `json:"cancellation"`
+	}{
+		Cancellation: map[string]string{"reason": reason},
+	}
+
+	data, err := json.Marshal(&wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	return changeStatus(driver, account, "cancel", data, true)
+}
+
+// Reactivate requests that the given cancelled account be reactivated. If
+// there are issues along the way, a non-nil error is returned. If there is a
+// billing problem, the error is a *BillingError. Otherwise, the error is nil
+// and the refreshed entity is returned.
+func Reactivate(driver Canceller, account *Entity) (*Entity, error) {
+	return changeStatus(driver, account, "reactivate", nil, false)
+}
+
+func changeStatus(driver Canceller, account *Entity, action string, data []byte, cancelling bool) (*Entity, error) {
+	pathParts := []string{"accounts", account.ID, action}
+
+	_, err := driver.Post(strings.Join(pathParts, "/"), nil, data)
+	if err != nil {
+		return nil, classify(account, err, cancelling)
+	}
+
+	return Find(driver, account.ID)
+}
+
+// classify types API errors by status code alone. Live environments only
+// block a cancellation, so a conflict during reactivation is passed along.
+func classify(account *Entity, err error, cancelling bool) error {
+	apiErr, ok := err.(*client.APIError)
+	if !ok {
+		return err
+	}
+
+	reason := strings.Join(upstreamErrors(apiErr.Body), ", ")
+
+	switch {
+	case apiErr.StatusCode == http.StatusPaymentRequired:
+		return &BillingError{Account: account, Reason: reason}
+	case apiErr.StatusCode == http.StatusConflict && cancelling:
+		return &LiveEnvironmentsError{Account: account, Reason: reason}
+	}
+
+	return err
+}
+
+func upstreamErrors(body []byte) []string {
+	wrapper := struct {
+		Errors []string `json:"errors,omitempty"`
+	}{}
+
+	if err := json.Unmarshal(body, &wrapper); err != nil || len(wrapper.Errors) == 0 {
+		return []string{string(body)}
+	}
+
+	return wrapper.Errors
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
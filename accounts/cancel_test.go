@@ -0,0 +1,198 @@
+package accounts
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/ess/maury/client"
+)
+
+type canceller struct {
+	reader
+	posted map[string][]byte
+	errors map[string]error
+}
+
+func (c *canceller) Post(path string, params url.Values, data []byte) ([]byte, error) {
+	if c.posted == nil {
+		c.posted = make(map[string][]byte)
+	}
+
+	c.posted[path] = data
+
+	if err, ok := c.errors[path]; ok {
+		return nil, err
+	}
+
+	return []byte(`{}`), nil
+}
+
+func (c *canceller) fail(path string, err error) {
+	if c.errors == nil {
+		c.errors = make(map[string]error)
+	}
+
+	c.errors[path] = err
+}
+
+func TestCancel(t *testing.T) {
+	account := &Entity{ID: "12345"}
+	path := "accounts/12345/cancel"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := &canceller{}
+		driver.set("accounts/12345", nil, `{"account" : {"id" : "12345", "cancelled_at" : "2018-06-01T12:30:00Z"}}`)
+
+		cancelled, err := Cancel(driver, account, "too many sausages")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it sends the reason", func(t *testing.T) {
+			expected := `{"cancellation":{"reason":"too many sausages"}}`
+
+			if string(driver.posted[path]) != expected {
+				t.Errorf("Expected '%s', got '%s'", expected, string(driver.posted[path]))
+			}
+		})
+
+		t.Run("it returns the refreshed entity", func(t *testing.T) {
+			if cancelled == nil || !cancelled.Cancelled() {
+				t.Errorf("Expected a cancelled account")
+			}
+		})
+	})
+
+	t.Run("when the account has live environments", func(t *testing.T) {
+		driver := &canceller{}
+		driver.fail(path, &client.APIError{
+			StatusCode: 409,
+			Body:       []byte(`{"errors" : ["Account has running environments"]}`),
+		})
+
+		cancelled, err := Cancel(driver, account, "")
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if cancelled != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+
+		t.Run("the error is a LiveEnvironmentsError", func(t *testing.T) {
+			if _, ok := err.(*LiveEnvironmentsError); !ok {
+				t.Errorf("Expected a *LiveEnvironmentsError, got %T", err)
+			}
+		})
+	})
+
+	t.Run("when the account has a billing problem", func(t *testing.T) {
+		driver := &canceller{}
+		driver.fail(path, &client.APIError{StatusCode: 402, Body: []byte(`Payment Required`)})
+
+		_, err := Cancel(driver, account, "")
+
+		t.Run("the error is a BillingError", func(t *testing.T) {
+			if _, ok := err.(*BillingError); !ok {
+				t.Errorf("Expected a *BillingError, got %T", err)
+			}
+		})
+	})
+
+	t.Run("when the API fails with a message that mentions billing", func(t *testing.T) {
+		driver := &canceller{}
+		failure := &client.APIError{
+			StatusCode: 500,
+			Body:       []byte(`{"errors" : ["billing service unavailable for this environment"]}`),
+		}
+		driver.fail(path, failure)
+
+		_, err := Cancel(driver, account, "")
+
+		t.Run("the error is passed along untyped", func(t *testing.T) {
+			if err != failure {
+				t.Errorf("Expected the original error, got %T", err)
+			}
+		})
+	})
+
+	t.Run("when the call fails for another reason", func(t *testing.T) {
+		driver := &canceller{}
+		failure := errors.New("connection refused")
+		driver.fail(path, failure)
+
+		_, err := Cancel(driver, account, "")
+
+		t.Run("the error is passed along", func(t *testing.T) {
+			if err != failure {
+				t.Errorf("Expected the original error, got %s", err)
+			}
+		})
+	})
+}
+
+func TestReactivate(t *testing.T) {
+	account := &Entity{ID: "12345"}
+	path := "accounts/12345/reactivate"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := &canceller{}
+		driver.set("accounts/12345", nil, `{"account" : {"id" : "12345", "cancelled_at" : null}}`)
+
+		reactivated, err := Reactivate(driver, account)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it posts to the reactivation endpoint", func(t *testing.T) {
+			if _, ok := driver.posted[path]; !ok {
+				t.Errorf("Expected a POST to %s", path)
+			}
+		})
+
+		t.Run("it returns the refreshed entity", func(t *testing.T) {
+			if reactivated == nil || reactivated.Cancelled() {
+				t.Errorf("Expected an active account")
+			}
+		})
+	})
+
+	t.Run("when the account has a billing problem", func(t *testing.T) {
+		driver := &canceller{}
+		driver.fail(path, &client.APIError{
+			StatusCode: 402,
+			Body:       []byte(`{"errors" : ["Billing details are invalid"]}`),
+		})
+
+		_, err := Reactivate(driver, account)
+
+		t.Run("the error is a BillingError", func(t *testing.T) {
+			if _, ok := err.(*BillingError); !ok {
+				t.Errorf("Expected a *BillingError, got %T", err)
+			}
+		})
+	})
+
+	t.Run("when the API reports a conflict", func(t *testing.T) {
+		driver := &canceller{}
+		failure := &client.APIError{
+			StatusCode: 409,
+			Body:       []byte(`{"errors" : ["Account has running environments"]}`),
+		}
+		driver.fail(path, failure)
+
+		_, err := Reactivate(driver, account)
+
+		t.Run("the error is not a LiveEnvironmentsError", func(t *testing.T) {
+			if _, ok := err.(*LiveEnvironmentsError); ok {
+				t.Errorf("Expected the original error, got a *LiveEnvironmentsError")
+			}
+		})
+	})
+}
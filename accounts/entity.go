@@ -2,22 +2,28 @@
 // the Accounts endpoint ont he Engine Yard API
 package accounts
 
+import (
+	"encoding/json"
+
+	"github.com/ess/maury/timestamp"
+)
+
 // Entity is a flat data structure that maps to an upstream Account
 type Entity struct {
 	ID string `json:"id,omitempty"`
 
 	// Account Details
-	Billable            bool   `json:"billable,omitempty"`
-	BillingRequired     bool   `json:"billing_required,omitempty"`
-	EmergencyContact    string `json:"emergency_contact,omitempty"`
-	Finalized           bool   `json:"finalized,omitempty"`
-	Name                string `json:"name,omitempty"`
-	Plan                string `json:"plan,omitempty"`
-	RdsManagementWebURI string `json:"rds_management_web_uri,omitempty"`
-	SignupVia           string `json:"signup_via"`
-	SupportPlan         string `json:"support_plan,omitempty"`
-	SupportTrialStatus  string `json:"support_trial_status"`
-	Type                string `json:"type,omitempty"`
+	Billable            bool               `json:"billable,omitempty"`
+	BillingRequired     bool               `json:"billing_required,omitempty"`
+	EmergencyContact    string             `json:"emergency_contact,omitempty"`
+	Finalized           bool               `json:"finalized,omitempty"`
+	Name                string             `json:"name,omitempty"`
+	Plan                Plan               `json:"plan,omitempty"`
+	RdsManagementWebURI string             `json:"rds_management_web_uri,omitempty"`
+	SignupVia           string             `json:"signup_via"`
+	SupportPlan         SupportPlan        `json:"support_plan,omitempty"`
+	SupportTrialStatus  SupportTrialStatus `json:"support_trial_status"`
+	Type                Type               `json:"type,omitempty"`
 
 	// Relation URLs
 	AccountNotes     string `json:"account_notes,omitempty"`
@@ -37,10 +43,39 @@ type Entity struct {
 	Users            string `json:"users,omitempty"`
 
 	// Timestamps
-	CanceledAt  string `json:"canceled_at,omitempty"`
-	CancelledAt string `json:"cancelled_at,omitempty"`
-	CreatedAt   string `json:"created_at,omitempty"`
-	Updatedat   string `json:"updated_at,omitempty"`
+	CancelledAt timestamp.Time `json:"cancelled_at,omitempty"`
+	CreatedAt   timestamp.Time `json:"created_at,omitempty"`
+	UpdatedAt   timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Cancelled returns true if the account has been cancelled
+func (account *Entity) Cancelled() bool {
+	return !account.CancelledAt.IsZero()
+}
+
+// UnmarshalJSON populates the entity from its JSON representation. The API
+// spells the cancellation timestamp both as canceled_at and cancelled_at, so
+// both are reconciled into CancelledAt.
+func (account *Entity) UnmarshalJSON(data []byte) error {
+	type entity Entity
+
+	wrapper := struct {
+		*entity
+		CanceledAt timestamp.Time `json:"canceled_at,omitempty"`
+	}{
+		entity: (*entity)(account),
+	}
+
+	err := json.Unmarshal(data, &wrapper)
+	if err != nil {
+		return err
+	}
+
+	if account.CancelledAt.IsZero() {
+		account.CancelledAt = wrapper.CanceledAt
+	}
+
+	return nil
 }
 
 // Copyright 2018 Dennis Walters
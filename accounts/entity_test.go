@@ -0,0 +1,59 @@
+package accounts
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEntity_UnmarshalJSON(t *testing.T) {
+	t.Run("when the account has not been cancelled", func(t *testing.T) {
+		account := &Entity{}
+		err := json.Unmarshal([]byte(`{"id" : "1", "cancelled_at" : null, "canceled_at" : null}`), account)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is not cancelled", func(t *testing.T) {
+			if account.Cancelled() {
+				t.Errorf("Expected the account to not be cancelled")
+			}
+		})
+	})
+
+	t.Run("when the API sends canceled_at", func(t *testing.T) {
+		account := &Entity{}
+		err := json.Unmarshal([]byte(`{"id" : "1", "canceled_at" : "2018-06-01T12:30:00Z"}`), account)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is cancelled", func(t *testing.T) {
+			if !account.Cancelled() {
+				t.Errorf("Expected the account to be cancelled")
+			}
+		})
+
+		t.Run("it retains the other fields", func(t *testing.T) {
+			if account.ID != "1" {
+				t.Errorf("Expected ID 1, got '%s'", account.ID)
+			}
+		})
+	})
+
+	t.Run("when the API sends cancelled_at", func(t *testing.T) {
+		account := &Entity{}
+		json.Unmarshal([]byte(`{"id" : "1", "cancelled_at" : "2018-06-01T12:30:00Z"}`), account)
+
+		t.Run("it is cancelled", func(t *testing.T) {
+			if !account.Cancelled() {
+				t.Errorf("Expected the account to be cancelled")
+			}
+		})
+	})
+}
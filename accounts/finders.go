@@ -3,9 +3,9 @@ package accounts
 import (
 	"encoding/json"
 	"net/url"
-	"strconv"
 	"strings"
 
+	"github.com/ess/maury/client"
 	"github.com/ess/maury/users"
 )
 
@@ -15,15 +15,17 @@ type Reader interface {
 }
 
 // All returns an array of account entities from the API. If params are
-// provided, they are passed along to the API for consideration.
-func All(driver Reader, params url.Values) []*Entity {
+// provided, they are passed along to the API for consideration. If there are
+// problems along the way, a non-nil error is returned.
+func All(driver Reader, params url.Values) ([]*Entity, error) {
 	return allPages(driver, "accounts", params)
 }
 
 // ForUser returns an array of account entities from the API scoped to the
 // given user. If params are provided, they are passed along to the API for
-// consideration.
-func ForUser(driver Reader, user *users.Entity, params url.Values) []*Entity {
+// consideration. If there are problems along the way, a non-nil error is
+// returned.
+func ForUser(driver Reader, user *users.Entity, params url.Values) ([]*Entity, error) {
 	pathParts := []string{"users", user.ID, "accounts"}
 
 	return allPages(driver, strings.Join(pathParts, "/"), params)
@@ -50,41 +52,28 @@ func Find(driver Reader, id string) (*Entity, error) {
 	return wrapper.Account, nil
 }
 
-func allPages(driver Reader, path string, params url.Values) []*Entity {
+func allPages(driver Reader, path string, params url.Values) ([]*Entity, error) {
 	var accounts []*Entity
 
-	maxResults := 100
-	page := 1
+	err := client.EachPage(driver, path, params, func(response []byte) (int, error) {
+		wrapper := struct {
+			Accounts []*Entity `json:"accounts,omitempty"`
+		}{}
 
-	if params == nil {
-		params = url.Values{}
-	}
-
-	params.Set("per_page", strconv.Itoa(maxResults))
-
-	wrapper := struct {
-		Accounts []*Entity `json:"accounts,omitempty"`
-	}{
-		Accounts: []*Entity{{}},
-	}
-
-	for len(wrapper.Accounts) > 0 {
-		params.Set("page", strconv.Itoa(page))
+		if err := json.Unmarshal(response, &wrapper); err != nil {
+			return 0, err
+		}
 
-		if response, err := driver.Get(path, params); err == nil {
-			if jerr := json.Unmarshal(response, &wrapper); jerr == nil {
-				accounts = append(accounts, wrapper.Accounts...)
+		accounts = append(accounts, wrapper.Accounts...)
 
-				if len(wrapper.Accounts) < maxResults {
-					break
-				}
-			}
-		}
+		return len(wrapper.Accounts), nil
+	})
 
-		page = page + 1
+	if err != nil {
+		return nil, err
 	}
 
-	return accounts
+	return accounts, nil
 }
 
 // Copyright 2018 Dennis Walters
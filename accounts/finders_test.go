@@ -77,7 +77,13 @@ func TestAll(t *testing.T) {
 
 		driver.set("accounts", params, `{"accounts" : []}`)
 
-		all := All(driver, nil)
+		all, err := All(driver, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
 
 		t.Run("it is empty", func(t *testing.T) {
 			if len(all) > 0 {
@@ -95,7 +101,7 @@ func TestAll(t *testing.T) {
 
 			driver.set("accounts", params, generate(1, 10))
 
-			all := All(driver, nil)
+			all, _ := All(driver, nil)
 
 			t.Run("it contains the entities the API returned", func(t *testing.T) {
 				if len(all) != 10 {
@@ -116,7 +122,7 @@ func TestAll(t *testing.T) {
 
 			driver.set("accounts", params, generate(101, 110))
 
-			all := All(driver, nil)
+			all, _ := All(driver, nil)
 
 			t.Run("it contains all of the entities the API returned", func(t *testing.T) {
 				if len(all) != 110 {
@@ -125,6 +131,46 @@ func TestAll(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("when the API fails partway through", func(t *testing.T) {
+		driver := &reader{}
+		params := url.Values{}
+		params.Set("page", "1")
+		params.Set("per_page", "100")
+
+		driver.set("accounts", params, generate(1, 100))
+
+		all, err := All(driver, nil)
+
+		t.Run("it returns the error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it returns no accounts", func(t *testing.T) {
+			if all != nil {
+				t.Errorf("Expected no accounts, got %d", len(all))
+			}
+		})
+	})
+
+	t.Run("when the API sends bad data", func(t *testing.T) {
+		driver := &reader{}
+		params := url.Values{}
+		params.Set("page", "1")
+		params.Set("per_page", "100")
+
+		driver.set("accounts", params, `{"accounts" : "sausages"}`)
+
+		_, err := All(driver, nil)
+
+		t.Run("it returns the error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
 }
 
 func TestForUser(t *testing.T) {
@@ -152,7 +198,13 @@ func TestForUser(t *testing.T) {
 
 		driver.set(path, params, `{"accounts" : []}`)
 
-		all := ForUser(driver, user, nil)
+		all, err := ForUser(driver, user, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
 
 		t.Run("it is empty", func(t *testing.T) {
 			if len(all) > 0 {
@@ -170,7 +222,7 @@ func TestForUser(t *testing.T) {
 
 			driver.set(path, params, generate(1, 10))
 
-			all := ForUser(driver, user, nil)
+			all, _ := ForUser(driver, user, nil)
 
 			t.Run("it contains the entities the API returned", func(t *testing.T) {
 				if len(all) != 10 {
@@ -191,7 +243,7 @@ func TestForUser(t *testing.T) {
 
 			driver.set(path, params, generate(101, 110))
 
-			all := ForUser(driver, user, nil)
+			all, _ := ForUser(driver, user, nil)
 
 			t.Run("it contains all of the entities the API returned", func(t *testing.T) {
 				if len(all) != 110 {
@@ -0,0 +1,106 @@
+package accounts
+
+// Plan is the billing plan for an account. The API may introduce plans that
+// are not listed here, and those values are retained as-is.
+type Plan string
+
+// Known billing plans
+const (
+	PlanTrial      Plan = "trial"
+	PlanStandard   Plan = "standard"
+	PlanEnterprise Plan = "enterprise"
+)
+
+// Known returns true if the plan is one of the known billing plans
+func (plan Plan) Known() bool {
+	switch plan {
+	case PlanTrial, PlanStandard, PlanEnterprise:
+		return true
+	}
+
+	return false
+}
+
+// SupportPlan is the support plan for an account. The API may introduce
+// support plans that are not listed here, and those values are retained as-is.
+type SupportPlan string
+
+// Known support plans, in ascending order of coverage
+const (
+	SupportPlanStandard SupportPlan = "standard"
+	SupportPlanPremium  SupportPlan = "premium"
+)
+
+var supportPlans = []SupportPlan{SupportPlanStandard, SupportPlanPremium}
+
+// Known returns true if the support plan is one of the known support plans
+func (plan SupportPlan) Known() bool {
+	return plan.rank() >= 0
+}
+
+func (plan SupportPlan) rank() int {
+	for rank, known := range supportPlans {
+		if plan == known {
+			return rank
+		}
+	}
+
+	return -1
+}
+
+// SupportTrialStatus is the state of an account's support plan trial. The API
+// may introduce statuses that are not listed here, and those values are
+// retained as-is.
+type SupportTrialStatus string
+
+// Known support trial statuses
+const (
+	SupportTrialAvailable SupportTrialStatus = "available"
+	SupportTrialActive    SupportTrialStatus = "active"
+	SupportTrialExpired   SupportTrialStatus = "expired"
+)
+
+// Known returns true if the status is one of the known support trial statuses
+func (status SupportTrialStatus) Known() bool {
+	switch status {
+	case SupportTrialAvailable, SupportTrialActive, SupportTrialExpired:
+		return true
+	}
+
+	return false
+}
+
+// Type is the kind of an account. The API may introduce account types that
+// are not listed here, and those values are retained as-is.
+type Type string
+
+// Known account types
+const (
+	TypeCustomer Type = "customer"
+	TypeInternal Type = "internal"
+	TypePartner  Type = "partner"
+)
+
+// Known returns true if the type is one of the known account types
+func (accountType Type) Known() bool {
+	switch accountType {
+	case TypeCustomer, TypeInternal, TypePartner:
+		return true
+	}
+
+	return false
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
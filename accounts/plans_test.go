@@ -0,0 +1,60 @@
+package accounts
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEntity_Plans(t *testing.T) {
+	t.Run("when the API sends known values", func(t *testing.T) {
+		account := &Entity{}
+		json.Unmarshal(
+			[]byte(`{"plan" : "standard", "support_plan" : "premium", "support_trial_status" : "expired", "type" : "customer"}`),
+			account,
+		)
+
+		t.Run("they are recognized", func(t *testing.T) {
+			if account.Plan != PlanStandard || !account.Plan.Known() {
+				t.Errorf("Expected a known plan, got '%s'", account.Plan)
+			}
+
+			if account.SupportPlan != SupportPlanPremium || !account.SupportPlan.Known() {
+				t.Errorf("Expected a known support plan, got '%s'", account.SupportPlan)
+			}
+
+			if account.SupportTrialStatus != SupportTrialExpired || !account.SupportTrialStatus.Known() {
+				t.Errorf("Expected a known support trial status, got '%s'", account.SupportTrialStatus)
+			}
+
+			if account.Type != TypeCustomer || !account.Type.Known() {
+				t.Errorf("Expected a known type, got '%s'", account.Type)
+			}
+		})
+	})
+
+	t.Run("when the API sends values we haven't heard of", func(t *testing.T) {
+		account := &Entity{}
+		err := json.Unmarshal(
+			[]byte(`{"plan" : "galactic", "support_plan" : "concierge", "type" : "reseller"}`),
+			account,
+		)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("the values are retained", func(t *testing.T) {
+			if account.Plan != "galactic" || account.SupportPlan != "concierge" || account.Type != "reseller" {
+				t.Errorf("Expected the upstream values to be retained")
+			}
+		})
+
+		t.Run("the values are not known", func(t *testing.T) {
+			if account.Plan.Known() || account.SupportPlan.Known() || account.Type.Known() {
+				t.Errorf("Expected the values to be unknown")
+			}
+		})
+	})
+}
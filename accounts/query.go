@@ -0,0 +1,203 @@
+package accounts
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query models a set of filters for finding accounts. The filters are passed
+// along to the API as params, and they can also be applied locally so that
+// results are consistent even when the API ignores a given filter.
+type Query struct {
+	name         string
+	nameContains string
+	plan         Plan
+	accountType  Type
+	billable     *bool
+	cancelled    *bool
+	before       time.Time
+	after        time.Time
+}
+
+// NewQuery returns an empty Query that matches all accounts
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Name restricts the query to accounts with exactly the given name
+func (query *Query) Name(name string) *Query {
+	query.name = name
+	return query
+}
+
+// NameContains restricts the query to accounts with names that contain the
+// given fragment, ignoring case. This filter is only applied locally.
+func (query *Query) NameContains(fragment string) *Query {
+	query.nameContains = fragment
+	return query
+}
+
+// Plan restricts the query to accounts on the given plan
+func (query *Query) Plan(plan Plan) *Query {
+	query.plan = plan
+	return query
+}
+
+// Type restricts the query to accounts of the given type
+func (query *Query) Type(accountType Type) *Query {
+	query.accountType = accountType
+	return query
+}
+
+// Billable restricts the query to accounts with the given billable status
+func (query *Query) Billable(billable bool) *Query {
+	query.billable = &billable
+	return query
+}
+
+// Cancelled restricts the query to accounts that have been cancelled
+func (query *Query) Cancelled() *Query {
+	cancelled := true
+	query.cancelled = &cancelled
+	return query
+}
+
+// Active restricts the query to accounts that have not been cancelled
+func (query *Query) Active() *Query {
+	cancelled := false
+	query.cancelled = &cancelled
+	return query
+}
+
+// CreatedBefore restricts the query to accounts created before the given time
+func (query *Query) CreatedBefore(before time.Time) *Query {
+	query.before = before
+	return query
+}
+
+// CreatedAfter restricts the query to accounts created after the given time
+func (query *Query) CreatedAfter(after time.Time) *Query {
+	query.after = after
+	return query
+}
+
+// Params returns the API params that represent the query
+func (query *Query) Params() url.Values {
+	params := url.Values{}
+
+	if len(query.name) > 0 {
+		params.Set("name", query.name)
+	}
+
+	if len(query.plan) > 0 {
+		params.Set("plan", string(query.plan))
+	}
+
+	if len(query.accountType) > 0 {
+		params.Set("type", string(query.accountType))
+	}
+
+	if query.billable != nil {
+		params.Set("billable", strconv.FormatBool(*query.billable))
+	}
+
+	if query.cancelled != nil {
+		params.Set("cancelled", strconv.FormatBool(*query.cancelled))
+	}
+
+	if !query.before.IsZero() {
+		params.Set("created_before", query.before.Format(time.RFC3339))
+	}
+
+	if !query.after.IsZero() {
+		params.Set("created_after", query.after.Format(time.RFC3339))
+	}
+
+	return params
+}
+
+// Matches returns true if the given account satisfies all of the query's
+// filters, false otherwise.
+func (query *Query) Matches(account *Entity) bool {
+	if account == nil {
+		return false
+	}
+
+	if len(query.name) > 0 && account.Name != query.name {
+		return false
+	}
+
+	if len(query.nameContains) > 0 &&
+		!strings.Contains(strings.ToLower(account.Name), strings.ToLower(query.nameContains)) {
+		return false
+	}
+
+	if len(query.plan) > 0 && account.Plan != query.plan {
+		return false
+	}
+
+	if len(query.accountType) > 0 && account.Type != query.accountType {
+		return false
+	}
+
+	if query.billable != nil && account.Billable != *query.billable {
+		return false
+	}
+
+	if query.cancelled != nil && account.Cancelled() != *query.cancelled {
+		return false
+	}
+
+	if !query.before.IsZero() && !account.CreatedAt.Before(query.before) {
+		return false
+	}
+
+	if !query.after.IsZero() && !account.CreatedAt.After(query.after) {
+		return false
+	}
+
+	return true
+}
+
+// Filter returns the members of the given collection that match the query
+func (query *Query) Filter(accounts []*Entity) []*Entity {
+	var matches []*Entity
+
+	for _, account := range accounts {
+		if query.Matches(account) {
+			matches = append(matches, account)
+		}
+	}
+
+	return matches
+}
+
+// Search returns an array of account entities from the API that match the
+// given query. The query is passed along to the API as params, and the
+// results are then filtered locally to account for any filters that the API
+// does not support. If there are problems along the way, a non-nil error is
+// returned.
+func Search(driver Reader, query *Query) ([]*Entity, error) {
+	accounts, err := All(driver, query.Params())
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Filter(accounts), nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,180 @@
+package accounts
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ess/maury/timestamp"
+)
+
+func TestQuery_Params(t *testing.T) {
+	t.Run("when the query is empty", func(t *testing.T) {
+		params := NewQuery().Params()
+
+		t.Run("it has no params", func(t *testing.T) {
+			if len(params) > 0 {
+				t.Errorf("Expected no params, got %s", params.Encode())
+			}
+		})
+	})
+
+	t.Run("when the query has filters", func(t *testing.T) {
+		after := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		params := NewQuery().
+			Name("sausages").
+			NameContains("sau").
+			Plan("standard").
+			Billable(true).
+			Active().
+			CreatedAfter(after).
+			Params()
+
+		expected := url.Values{}
+		expected.Set("name", "sausages")
+		expected.Set("plan", "standard")
+		expected.Set("billable", "true")
+		expected.Set("cancelled", "false")
+		expected.Set("created_after", "2018-01-01T00:00:00Z")
+
+		t.Run("it has the expected params", func(t *testing.T) {
+			if params.Encode() != expected.Encode() {
+				t.Errorf("Expected '%s', got '%s'", expected.Encode(), params.Encode())
+			}
+		})
+	})
+}
+
+func TestQuery_Matches(t *testing.T) {
+	created := timestamp.New(time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	account := &Entity{
+		ID:        "1",
+		Name:      "Sausage Factory",
+		Plan:      "standard",
+		Billable:  true,
+		CreatedAt: created,
+	}
+
+	cancelled := &Entity{
+		ID:          "2",
+		Name:        "Closed Sausage Factory",
+		CancelledAt: created,
+	}
+
+	t.Run("when the query is empty", func(t *testing.T) {
+		t.Run("it matches everything", func(t *testing.T) {
+			if !NewQuery().Matches(account) || !NewQuery().Matches(cancelled) {
+				t.Errorf("Expected the empty query to match all accounts")
+			}
+		})
+	})
+
+	t.Run("when filtering by name fragment", func(t *testing.T) {
+		query := NewQuery().NameContains("sausage factory")
+
+		t.Run("it ignores case", func(t *testing.T) {
+			if !query.Matches(account) {
+				t.Errorf("Expected a case-insensitive match")
+			}
+		})
+
+		t.Run("it rejects non-matches", func(t *testing.T) {
+			if NewQuery().NameContains("bakery").Matches(account) {
+				t.Errorf("Expected no match")
+			}
+		})
+	})
+
+	t.Run("when filtering by cancellation", func(t *testing.T) {
+		t.Run("active matches only active accounts", func(t *testing.T) {
+			query := NewQuery().Active()
+
+			if !query.Matches(account) || query.Matches(cancelled) {
+				t.Errorf("Expected only the active account to match")
+			}
+		})
+
+		t.Run("cancelled matches only cancelled accounts", func(t *testing.T) {
+			query := NewQuery().Cancelled()
+
+			if query.Matches(account) || !query.Matches(cancelled) {
+				t.Errorf("Expected only the cancelled account to match")
+			}
+		})
+	})
+
+	t.Run("when filtering by creation time", func(t *testing.T) {
+		t.Run("it matches accounts in range", func(t *testing.T) {
+			query := NewQuery().
+				CreatedAfter(created.AddDate(0, 0, -1)).
+				CreatedBefore(created.AddDate(0, 0, 1))
+
+			if !query.Matches(account) {
+				t.Errorf("Expected a match")
+			}
+		})
+
+		t.Run("it rejects accounts out of range", func(t *testing.T) {
+			query := NewQuery().CreatedAfter(created.AddDate(0, 0, 1))
+
+			if query.Matches(account) {
+				t.Errorf("Expected no match")
+			}
+		})
+	})
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("when the API ignores a filter", func(t *testing.T) {
+		driver := &reader{}
+		query := NewQuery().Plan("standard")
+
+		params := query.Params()
+		params.Set("page", "1")
+		params.Set("per_page", "100")
+
+		driver.set(
+			"accounts",
+			params,
+			`{"accounts" : [{"id" : "1", "plan" : "standard"}, {"id" : "2", "plan" : "legacy"}]}`,
+		)
+
+		results, err := Search(driver, query)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it filters the results locally", func(t *testing.T) {
+			if len(results) != 1 {
+				t.Fatalf("Expected 1 result, got %d", len(results))
+			}
+
+			if results[0].ID != "1" {
+				t.Errorf("Expected account 1, got account %s", results[0].ID)
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := &reader{}
+
+		results, err := Search(driver, NewQuery().Plan("standard"))
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("there are no results", func(t *testing.T) {
+			if results != nil {
+				t.Errorf("Expected no results")
+			}
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package accounts
+
+import (
+	"errors"
+)
+
+// InvalidSupportPlanError is returned when a support plan change is requested
+// that the API would not accept
+type InvalidSupportPlanError struct {
+	Plan SupportPlan
+}
+
+func (err *InvalidSupportPlanError) Error() string {
+	return "'" + string(err.Plan) + "' is not a valid support plan"
+}
+
+// UpgradeSupportPlan requests that the given account be moved to the given
+// support plan. If the plan is not a known support plan, the error is an
+// *InvalidSupportPlanError, and no request is sent to the API. Otherwise, the
+// result is the same as that of Update.
+func UpgradeSupportPlan(driver Updater, account *Entity, plan SupportPlan) (*Entity, error) {
+	if !plan.Known() {
+		return nil, &InvalidSupportPlanError{Plan: plan}
+	}
+
+	if plan.rank() <= account.SupportPlan.rank() {
+		return nil, errors.New(
+			"Support plan '" + string(plan) + "' is not an upgrade from '" + string(account.SupportPlan) + "'",
+		)
+	}
+
+	return Update(driver, account, &Changes{SupportPlan: plan})
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
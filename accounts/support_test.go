@@ -0,0 +1,68 @@
+package accounts
+
+import (
+	"testing"
+)
+
+func TestUpgradeSupportPlan(t *testing.T) {
+	path := "accounts/12345"
+	account := &Entity{ID: "12345", SupportPlan: SupportPlanStandard}
+
+	t.Run("when the plan is an upgrade", func(t *testing.T) {
+		driver := &updater{}
+		driver.set(path, `{"account" : {"id" : "12345", "support_plan" : "premium"}}`)
+
+		upgraded, err := UpgradeSupportPlan(driver, account, SupportPlanPremium)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("the entity has the new plan", func(t *testing.T) {
+			if upgraded.SupportPlan != SupportPlanPremium {
+				t.Errorf("Expected '%s', got '%s'", SupportPlanPremium, upgraded.SupportPlan)
+			}
+		})
+	})
+
+	t.Run("when the plan is unknown", func(t *testing.T) {
+		driver := &updater{}
+		driver.set(path, `{"account" : {"id" : "12345", "support_plan" : "platinum"}}`)
+
+		upgraded, err := UpgradeSupportPlan(driver, account, "platinum")
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if upgraded != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+
+		t.Run("the error is an InvalidSupportPlanError", func(t *testing.T) {
+			if _, ok := err.(*InvalidSupportPlanError); !ok {
+				t.Errorf("Expected an *InvalidSupportPlanError, got %T", err)
+			}
+		})
+	})
+
+	t.Run("when the plan is not an upgrade", func(t *testing.T) {
+		driver := &updater{}
+		driver.set(path, `{"account" : {"id" : "12345", "support_plan" : "standard"}}`)
+
+		premium := &Entity{ID: "12345", SupportPlan: SupportPlanPremium}
+		upgraded, err := UpgradeSupportPlan(driver, premium, SupportPlanStandard)
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if upgraded != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+
+		t.Run("the error is not nil", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
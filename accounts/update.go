@@ -2,9 +2,13 @@ package accounts
 
 import (
 	"encoding/json"
+	"errors"
 	"net/url"
 )
 
+// ErrNoChanges is returned when an update is requested without any changes
+var ErrNoChanges = errors.New("Changes are required to update an account")
+
 // Updater provides an interface for the update functions to talk to the API
 type Updater interface {
 	Put(string, url.Values, []byte) ([]byte, error)
@@ -12,16 +16,24 @@ type Updater interface {
 
 // Changes models the aspects of an Account that we are allowed to change
 type Changes struct {
-	Name             string `json:"name,omitempty"`
-	EmergencyContact string `json:"emergency_contact,omitempty"`
-	SupportPlan      string `json:"support_plan,omitempty"`
+	Name             string      `json:"name,omitempty"`
+	EmergencyContact string      `json:"emergency_contact,omitempty"`
+	SupportPlan      SupportPlan `json:"support_plan,omitempty"`
 }
 
 // Update requests that an account be updated on the API to match the provided
 // changes. If there are issues along the way, a non-nil error is returned.
-// Otherwise, the error is nil and the returned entity contains the requested
-// changes.
+// If the changes are nil, the error is ErrNoChanges. If the changes include an unknown support plan, the error is an
+// *InvalidSupportPlanError and no request is sent to the API. Otherwise, the
+// error is nil and the returned entity contains the requested changes.
 func Update(driver Updater, account *Entity, changes *Changes) (*Entity, error) {
+	if changes == nil {
+		return nil, ErrNoChanges
+	}
+
+	if len(changes.SupportPlan) > 0 && !changes.SupportPlan.Known() {
+		return nil, &InvalidSupportPlanError{Plan: changes.SupportPlan}
+	}
 
 	wrappedChanges := struct {
 		Account *Changes `json:"account,omitempty"`
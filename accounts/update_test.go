@@ -186,4 +186,44 @@ func TestUpdate(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("when updating the support plan", func(t *testing.T) {
+		t.Run("and the plan is unknown", func(t *testing.T) {
+			driver := &updater{}
+			driver.set(path, generate(id, name, emergency))
+
+			updated, err := Update(driver, original, &Changes{SupportPlan: "platinum"})
+
+			t.Run("the entity is nil", func(t *testing.T) {
+				if updated != nil {
+					t.Errorf("Expected a nil entity")
+				}
+			})
+
+			t.Run("the error is an InvalidSupportPlanError", func(t *testing.T) {
+				if _, ok := err.(*InvalidSupportPlanError); !ok {
+					t.Errorf("Expected an *InvalidSupportPlanError, got %T", err)
+				}
+			})
+		})
+	})
+
+	t.Run("when there are no changes", func(t *testing.T) {
+		driver := &updater{}
+		driver.set(path, generate(id, name, emergency))
+
+		updated, err := Update(driver, original, nil)
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if updated != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+
+		t.Run("the error is ErrNoChanges", func(t *testing.T) {
+			if err != ErrNoChanges {
+				t.Errorf("Expected ErrNoChanges, got %v", err)
+			}
+		})
+	})
 }
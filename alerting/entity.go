@@ -0,0 +1,83 @@
+// Package alerting provides the data structures and functions for modeling
+// the alerting settings of accounts and environments on the Engine Yard API.
+// The settings control where server alerts are sent and when they are
+// raised.
+package alerting
+
+import (
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to upstream Alerting Settings
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Alerting Details
+	Emails     []string    `json:"emails,omitempty"`
+	Thresholds *Thresholds `json:"thresholds,omitempty"`
+	Webhooks   []*Webhook  `json:"webhooks,omitempty"`
+
+	// Relation URLs
+	Account     string `json:"account,omitempty"`
+	Environment string `json:"environment,omitempty"`
+
+	// Timestamps
+	CreatedAt timestamp.Time `json:"created_at,omitempty"`
+	UpdatedAt timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Severity is how serious an alert is. The API may introduce severities that
+// are not listed here, and those values are retained as-is.
+type Severity string
+
+// Known alert severities
+const (
+	SeverityWarning Severity = "warning"
+	SeverityFailure Severity = "failure"
+	SeverityOkay    Severity = "okay"
+)
+
+// Known returns true if the severity is one of the known alert severities
+func (severity Severity) Known() bool {
+	switch severity {
+	case SeverityWarning, SeverityFailure, SeverityOkay:
+		return true
+	}
+
+	return false
+}
+
+// Webhook is a URL that alerts are posted to. If Severities is empty, alerts
+// of every severity are posted.
+type Webhook struct {
+	Severities []Severity `json:"severities,omitempty"`
+	URL        string     `json:"url,omitempty"`
+}
+
+// Thresholds are the resource usage levels at which alerts are raised
+type Thresholds struct {
+	CPU    *Threshold `json:"cpu,omitempty"`
+	Disk   *Threshold `json:"disk,omitempty"`
+	Memory *Threshold `json:"memory,omitempty"`
+}
+
+// Threshold is a pair of usage percentages at which warning and failure
+// alerts are raised
+type Threshold struct {
+	Failure int `json:"failure,omitempty"`
+	Warning int `json:"warning,omitempty"`
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,148 @@
+package alerting
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/environments"
+)
+
+// ErrNoChanges is returned when an update is requested without any changes
+var ErrNoChanges = errors.New("Changes are required to update alerting settings")
+
+// Reader provides an interface for the finder functions to talk to the API
+type Reader interface {
+	Get(string, url.Values) ([]byte, error)
+}
+
+// Updater provides an interface for the update functions to talk to the API
+type Updater interface {
+	Put(string, url.Values, []byte) ([]byte, error)
+}
+
+// Changes models the aspects of the alerting settings that we are allowed to
+// change. Fields that are nil are left as they are. The email and webhook
+// lists replace the current lists outright, so pointing them at an empty list
+// clears them.
+type Changes struct {
+	Emails     *[]string   `json:"emails,omitempty"`
+	Thresholds *Thresholds `json:"thresholds,omitempty"`
+	Webhooks   *[]*Webhook `json:"webhooks,omitempty"`
+}
+
+// EmailList returns a list of email addresses suitable for Changes. Calling it
+// with no addresses returns an empty list, which clears the emails.
+func EmailList(emails ...string) *[]string {
+	list := make([]string, 0, len(emails))
+	list = append(list, emails...)
+
+	return &list
+}
+
+// WebhookList returns a list of webhooks suitable for Changes. Calling it with
+// no webhooks returns an empty list, which clears the webhooks.
+func WebhookList(webhooks ...*Webhook) *[]*Webhook {
+	list := make([]*Webhook, 0, len(webhooks))
+	list = append(list, webhooks...)
+
+	return &list
+}
+
+// ForAccount queries the API for the alerting settings of the given account.
+// If there are problems along the way, a non-nil error is returned.
+// Otherwise, the error is nil and the entity is populated.
+func ForAccount(driver Reader, account *accounts.Entity) (*Entity, error) {
+	return find(driver, settingsPath("accounts", account.ID))
+}
+
+// ForEnvironment queries the API for the alerting settings of the given
+// environment. If there are problems along the way, a non-nil error is
+// returned. Otherwise, the error is nil and the entity is populated.
+func ForEnvironment(driver Reader, environment *environments.Entity) (*Entity, error) {
+	return find(driver, settingsPath("environments", environment.ID))
+}
+
+// UpdateAccount requests that the alerting settings of the given account be
+// updated to match the provided changes. If the changes are nil, the error is
+// ErrNoChanges. The changes are validated before anything is sent to the API,
+// and if they are invalid, the error is a *ValidationError. Otherwise, the
+// error is nil and the returned entity contains the requested changes.
+func UpdateAccount(driver Updater, account *accounts.Entity, changes *Changes) (*Entity, error) {
+	return update(driver, settingsPath("accounts", account.ID), changes)
+}
+
+// UpdateEnvironment requests that the alerting settings of the given
+// environment be updated to match the provided changes, with the same
+// validation as UpdateAccount
+func UpdateEnvironment(driver Updater, environment *environments.Entity, changes *Changes) (*Entity, error) {
+	return update(driver, settingsPath("environments", environment.ID), changes)
+}
+
+func find(driver Reader, path string) (*Entity, error) {
+	response, err := driver.Get(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(response)
+}
+
+func update(driver Updater, path string, changes *Changes) (*Entity, error) {
+	if err := changes.Validate(); err != nil {
+		return nil, err
+	}
+
+	wrappedChanges := struct {
+		Alerting *Changes `json:"alerting,omitempty"`
+	}{
+		Alerting: changes,
+	}
+
+	data, err := json.Marshal(&wrappedChanges)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := driver.Put(path, nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(response)
+}
+
+func parse(response []byte) (*Entity, error) {
+	wrapper := struct {
+		Alerting *Entity `json:"alerting,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Alerting, nil
+}
+
+func settingsPath(collection string, id string) string {
+	pathParts := []string{collection, id, "alerting"}
+
+	return strings.Join(pathParts, "/")
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,187 @@
+package alerting
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+)
+
+const settings = `{"alerting" : {
+	"id" : "1",
+	"emails" : ["ops@example.com"],
+	"webhooks" : [{"url" : "https://hooks.example.com/ey", "severities" : ["failure"]}],
+	"thresholds" : {"cpu" : {"warning" : 80, "failure" : 95}}
+}}`
+
+func TestForAccount(t *testing.T) {
+	t.Run("when the account has settings", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "accounts/1/alerting", nil, settings)
+
+		result, err := ForAccount(driver, &accounts.Entity{ID: "1"})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is populated", func(t *testing.T) {
+			if result == nil || len(result.Emails) != 1 || len(result.Webhooks) != 1 {
+				t.Fatalf("Expected an email and a webhook")
+			}
+
+			if result.Webhooks[0].Severities[0] != SeverityFailure {
+				t.Errorf("Expected the webhook to receive failures")
+			}
+
+			if result.Thresholds == nil || result.Thresholds.CPU.Failure != 95 {
+				t.Errorf("Expected a CPU failure threshold of 95")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "accounts/1/alerting", nil, errors.New("nope"))
+
+		result, err := ForAccount(driver, &accounts.Entity{ID: "1"})
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || result != nil {
+				t.Errorf("Expected an error and no entity")
+			}
+		})
+	})
+}
+
+func TestForEnvironment(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("GET", "environments/2/alerting", nil, settings)
+
+	result, err := ForEnvironment(driver, &environments.Entity{ID: "2"})
+
+	t.Run("it returns the settings for the environment", func(t *testing.T) {
+		if err != nil || result == nil || result.ID != "1" {
+			t.Errorf("Expected settings 1, got error %v", err)
+		}
+	})
+}
+
+func TestUpdateAccount(t *testing.T) {
+	account := &accounts.Entity{ID: "1"}
+
+	t.Run("when the changes are valid", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("PUT", "accounts/1/alerting", nil, settings)
+
+		result, err := UpdateAccount(driver, account, &Changes{Emails: EmailList("ops@example.com")})
+
+		t.Run("it returns the updated settings", func(t *testing.T) {
+			if err != nil || result == nil || result.Emails[0] != "ops@example.com" {
+				t.Errorf("Expected the updated settings, got error %v", err)
+			}
+		})
+
+		t.Run("it sends only the changes", func(t *testing.T) {
+			body := make(map[string]map[string]interface{})
+			json.Unmarshal(driver.Calls()[0].Data, &body)
+
+			if len(body["alerting"]) != 1 || body["alerting"]["emails"] == nil {
+				t.Errorf("Unexpected body %v", body)
+			}
+		})
+	})
+
+	t.Run("when the changes empty the lists", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("PUT", "accounts/1/alerting", nil, settings)
+
+		_, err := UpdateAccount(driver, account, &Changes{Emails: EmailList(), Webhooks: WebhookList()})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it sends empty lists", func(t *testing.T) {
+			if body := string(driver.Calls()[0].Data); body != `{"alerting":{"emails":[],"webhooks":[]}}` {
+				t.Errorf("Unexpected body %s", body)
+			}
+		})
+	})
+
+	t.Run("when the changes leave the lists alone", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("PUT", "accounts/1/alerting", nil, settings)
+
+		UpdateAccount(driver, account, &Changes{Thresholds: &Thresholds{CPU: &Threshold{Warning: 80, Failure: 95}}})
+
+		t.Run("it does not send the lists", func(t *testing.T) {
+			body := make(map[string]map[string]interface{})
+			json.Unmarshal(driver.Calls()[0].Data, &body)
+
+			if _, ok := body["alerting"]["emails"]; ok {
+				t.Errorf("Unexpected emails in %v", body)
+			}
+
+			if _, ok := body["alerting"]["webhooks"]; ok {
+				t.Errorf("Unexpected webhooks in %v", body)
+			}
+		})
+	})
+
+	t.Run("when there are no changes", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := UpdateAccount(driver, account, nil)
+
+		t.Run("the error is ErrNoChanges", func(t *testing.T) {
+			if err != ErrNoChanges {
+				t.Errorf("Expected ErrNoChanges, got %v", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "accounts/1/alerting")
+		})
+	})
+
+	t.Run("when the changes are invalid", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := UpdateAccount(driver, account, &Changes{Emails: EmailList("not an email")})
+
+		t.Run("the error is a ValidationError", func(t *testing.T) {
+			if _, ok := err.(*ValidationError); !ok {
+				t.Errorf("Expected a *ValidationError, got %T", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "accounts/1/alerting")
+		})
+	})
+}
+
+func TestUpdateEnvironment(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("PUT", "environments/2/alerting", nil, settings)
+
+	_, err := UpdateEnvironment(driver, &environments.Entity{ID: "2"}, &Changes{
+		Thresholds: &Thresholds{CPU: &Threshold{Warning: 80, Failure: 95}},
+	})
+
+	t.Run("it updates the settings for the environment", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+
+		driver.AssertCalled(t, "environments/2/alerting", nil)
+	})
+}
@@ -0,0 +1,135 @@
+package alerting
+
+import (
+	"net/mail"
+	"net/url"
+	"strconv"
+)
+
+// ValidationError is returned when alerting changes can't be sent to the API
+// because one of their fields is invalid
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (err *ValidationError) Error() string {
+	return "Invalid " + err.Field + ": " + err.Reason
+}
+
+// Validate checks that every email address, webhook and threshold in the
+// changes is valid. If one isn't, the error is a *ValidationError. If the
+// changes are nil, the error is ErrNoChanges.
+func (changes *Changes) Validate() error {
+	if changes == nil {
+		return ErrNoChanges
+	}
+
+	if changes.Emails != nil {
+		for _, email := range *changes.Emails {
+			if err := validateEmail(email); err != nil {
+				return err
+			}
+		}
+	}
+
+	if changes.Webhooks != nil {
+		for _, webhook := range *changes.Webhooks {
+			if err := webhook.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if changes.Thresholds != nil {
+		return changes.Thresholds.Validate()
+	}
+
+	return nil
+}
+
+// Validate checks that the webhook has an absolute HTTP or HTTPS URL and only
+// known severities. If it doesn't, or if the webhook is nil, the error is a
+// *ValidationError.
+func (webhook *Webhook) Validate() error {
+	if webhook == nil {
+		return &ValidationError{Field: "webhook", Reason: "the webhook is missing"}
+	}
+
+	link, err := url.Parse(webhook.URL)
+	if err != nil || (link.Scheme != "http" && link.Scheme != "https") || len(link.Host) == 0 {
+		return &ValidationError{Field: "webhook", Reason: "'" + webhook.URL + "' is not an HTTP or HTTPS URL"}
+	}
+
+	for _, severity := range webhook.Severities {
+		if !severity.Known() {
+			return &ValidationError{Field: "webhook", Reason: "unknown severity '" + string(severity) + "'"}
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that each threshold is a percentage and that warnings are
+// raised before failures. If they aren't, the error is a *ValidationError.
+func (thresholds *Thresholds) Validate() error {
+	named := []struct {
+		name      string
+		threshold *Threshold
+	}{
+		{"cpu", thresholds.CPU},
+		{"disk", thresholds.Disk},
+		{"memory", thresholds.Memory},
+	}
+
+	for _, entry := range named {
+		if entry.threshold == nil {
+			continue
+		}
+
+		if err := entry.threshold.validate(entry.name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (threshold *Threshold) validate(name string) error {
+	field := name + " threshold"
+
+	for _, level := range []int{threshold.Warning, threshold.Failure} {
+		if level < 1 || level > 100 {
+			return &ValidationError{Field: field, Reason: strconv.Itoa(level) + " is not a percentage between 1 and 100"}
+		}
+	}
+
+	if threshold.Warning >= threshold.Failure {
+		return &ValidationError{Field: field, Reason: "the warning level must be below the failure level"}
+	}
+
+	return nil
+}
+
+func validateEmail(email string) error {
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return &ValidationError{Field: "email", Reason: "'" + email + "' is not a bare email address"}
+	}
+
+	return nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
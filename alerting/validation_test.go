@@ -0,0 +1,57 @@
+package alerting
+
+import (
+	"testing"
+)
+
+func TestChanges_Validate(t *testing.T) {
+	t.Run("when the changes are valid", func(t *testing.T) {
+		changes := &Changes{
+			Emails:   EmailList("ops@example.com", "oncall+ey@example.com"),
+			Webhooks: WebhookList(&Webhook{URL: "https://hooks.example.com/ey", Severities: []Severity{SeverityFailure}}),
+			Thresholds: &Thresholds{
+				CPU:  &Threshold{Warning: 80, Failure: 95},
+				Disk: &Threshold{Warning: 75, Failure: 90},
+			},
+		}
+
+		t.Run("there is no error", func(t *testing.T) {
+			if err := changes.Validate(); err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	})
+
+	invalid := map[string]*Changes{
+		"a malformed email":              {Emails: EmailList("ops")},
+		"an email with a display name":   {Emails: EmailList("Ops <ops@example.com>")},
+		"a webhook without a scheme":     {Webhooks: WebhookList(&Webhook{URL: "hooks.example.com/ey"})},
+		"a webhook with another scheme":  {Webhooks: WebhookList(&Webhook{URL: "ftp://hooks.example.com/ey"})},
+		"a webhook with a bad severity":  {Webhooks: WebhookList(&Webhook{URL: "https://hooks.example.com", Severities: []Severity{"panic"}})},
+		"a missing webhook":              {Webhooks: WebhookList(nil)},
+		"a threshold over 100 percent":   {Thresholds: &Thresholds{Memory: &Threshold{Warning: 90, Failure: 110}}},
+		"a threshold with no warning":    {Thresholds: &Thresholds{CPU: &Threshold{Failure: 90}}},
+		"a warning above the failure":    {Thresholds: &Thresholds{Disk: &Threshold{Warning: 95, Failure: 90}}},
+		"a warning equal to the failure": {Thresholds: &Thresholds{Disk: &Threshold{Warning: 90, Failure: 90}}},
+	}
+
+	t.Run("when the changes are nil", func(t *testing.T) {
+		var changes *Changes
+
+		t.Run("the error is ErrNoChanges", func(t *testing.T) {
+			if err := changes.Validate(); err != ErrNoChanges {
+				t.Errorf("Expected ErrNoChanges, got %v", err)
+			}
+		})
+	})
+
+	for description, changes := range invalid {
+		t.Run("when the changes have "+description, func(t *testing.T) {
+			t.Run("the error is a ValidationError", func(t *testing.T) {
+				if _, ok := changes.Validate().(*ValidationError); !ok {
+					t.Errorf("Expected a *ValidationError")
+				}
+			})
+		})
+	}
+}
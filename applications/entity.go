@@ -0,0 +1,43 @@
+// Package applications provides the data structures and functions for
+// modeling the Applications endpoint on the Engine Yard API
+package applications
+
+import (
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to an upstream Application
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Application Details
+	Language   string `json:"language,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Type       string `json:"type,omitempty"`
+
+	// Relation URLs
+	Account      string `json:"account,omitempty"`
+	Deployments  string `json:"deployments,omitempty"`
+	Environments string `json:"environments,omitempty"`
+	Keypairs     string `json:"keypairs,omitempty"`
+
+	// Timestamps
+	CreatedAt timestamp.Time `json:"created_at,omitempty"`
+	DeletedAt timestamp.Time `json:"deleted_at,omitempty"`
+	UpdatedAt timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,158 @@
+package applications
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/maurytest"
+)
+
+func TestAll(t *testing.T) {
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "applications", nil, `{"applications" : [{"id" : "1"}, {"id" : "2"}]}`)
+
+		result, err := All(driver, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns all of the applications", func(t *testing.T) {
+			if len(result) != 2 {
+				t.Errorf("Expected 2 applications, got %d", len(result))
+			}
+		})
+	})
+
+	t.Run("when there are several pages", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "applications", maurytest.Page(1, nil), maurytest.Collection("applications", 1, 100))
+		driver.Respond("GET", "applications", maurytest.Page(2, nil), maurytest.Collection("applications", 101, 110))
+
+		result, err := All(driver, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the applications from every page", func(t *testing.T) {
+			if len(result) != 110 {
+				t.Errorf("Expected 110 applications, got %d", len(result))
+			}
+		})
+
+		t.Run("it stops after the last page", func(t *testing.T) {
+			if calls := driver.CallCount("applications"); calls != 2 {
+				t.Errorf("Expected 2 calls, got %d", calls)
+			}
+		})
+	})
+
+	t.Run("when the API fails partway through", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "applications", maurytest.Page(1, nil), maurytest.Collection("applications", 1, 100))
+		driver.Fail("GET", "applications", maurytest.Page(2, nil), errors.New("nope"))
+
+		result, err := All(driver, nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("there are no results", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no results")
+			}
+		})
+
+		t.Run("it stops requesting pages", func(t *testing.T) {
+			if calls := driver.CallCount("applications"); calls != 2 {
+				t.Errorf("Expected 2 calls, got %d", calls)
+			}
+		})
+	})
+}
+
+func TestForAccount(t *testing.T) {
+	account := &accounts.Entity{ID: "1"}
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "accounts/1/applications", nil, `{"applications" : [{"id" : "3"}]}`)
+
+		result, err := ForAccount(driver, account, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the applications for the account", func(t *testing.T) {
+			if len(result) != 1 || result[0].ID != "3" {
+				t.Errorf("Expected only application 3")
+			}
+		})
+	})
+
+	t.Run("when the API sends bad data", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "accounts/1/applications", nil, `{"applications" : "sausages"}`)
+
+		_, err := ForAccount(driver, account, nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestFind(t *testing.T) {
+	t.Run("when the application exists", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "applications/1", nil, `{"application" : {"id" : "1", "name" : "shop"}}`)
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is populated", func(t *testing.T) {
+			if result == nil || result.Name != "shop" {
+				t.Errorf("Expected application 'shop'")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "applications/1", nil, errors.New("nope"))
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+	})
+}
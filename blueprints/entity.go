@@ -0,0 +1,73 @@
+// Package blueprints provides the data structures and functions for modeling
+// the Blueprints endpoint on the Engine Yard API. A blueprint records the
+// server layout of an environment so that other environments can be booted
+// with the same layout.
+package blueprints
+
+import (
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to an upstream Blueprint
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Blueprint Details
+	Data *Data  `json:"data,omitempty"`
+	Name string `json:"name,omitempty"`
+
+	// Relation URLs
+	Account     string `json:"account,omitempty"`
+	Environment string `json:"environment,omitempty"`
+
+	// Timestamps
+	CreatedAt timestamp.Time `json:"created_at,omitempty"`
+	UpdatedAt timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Data describes the servers in a blueprint, grouped by their role
+type Data struct {
+	AppInstances []*Instance `json:"app_instances,omitempty"`
+	DBMaster     []*Instance `json:"db_master,omitempty"`
+	DBSlaves     []*Instance `json:"db_slaves,omitempty"`
+	Utils        []*Instance `json:"utils,omitempty"`
+}
+
+// Instance describes a single server in a blueprint
+type Instance struct {
+	Flavor     string `json:"flavor,omitempty"`
+	Name       string `json:"name,omitempty"`
+	VolumeIOPS int    `json:"volume_iops,omitempty"`
+	VolumeSize int    `json:"volume_size,omitempty"`
+}
+
+// Instances returns every server in the blueprint, regardless of role. A nil
+// Data, as for a blueprint that the API returned without data, has none.
+func (data *Data) Instances() []*Instance {
+	var instances []*Instance
+
+	if data == nil {
+		return instances
+	}
+
+	instances = append(instances, data.AppInstances...)
+	instances = append(instances, data.DBMaster...)
+	instances = append(instances, data.DBSlaves...)
+	instances = append(instances, data.Utils...)
+
+	return instances
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,31 @@
+package blueprints
+
+import (
+	"testing"
+)
+
+func TestData_Instances(t *testing.T) {
+	t.Run("when there are servers in several roles", func(t *testing.T) {
+		data := &Data{
+			AppInstances: []*Instance{{Name: "app"}},
+			DBMaster:     []*Instance{{Name: "db"}},
+			Utils:        []*Instance{{Name: "redis"}, {Name: "sidekiq"}},
+		}
+
+		t.Run("it returns all of them", func(t *testing.T) {
+			if len(data.Instances()) != 4 {
+				t.Errorf("Expected 4 instances, got %d", len(data.Instances()))
+			}
+		})
+	})
+
+	t.Run("when there is no data", func(t *testing.T) {
+		var data *Data
+
+		t.Run("it returns no instances", func(t *testing.T) {
+			if len(data.Instances()) != 0 {
+				t.Errorf("Expected no instances")
+			}
+		})
+	})
+}
@@ -0,0 +1,117 @@
+package blueprints
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+)
+
+func TestForEnvironment(t *testing.T) {
+	path := "environments/2/blueprints"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"blueprints" : [{"id" : "1"}, {"id" : "2"}]}`)
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the blueprints for the environment", func(t *testing.T) {
+			if len(result) != 2 {
+				t.Errorf("Expected 2 blueprints")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, maurytest.Page(1, nil), maurytest.Collection("blueprints", 1, 100))
+		driver.Fail("GET", path, maurytest.Page(2, nil), errors.New("nope"))
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("there are no results", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no results")
+			}
+		})
+
+		t.Run("it stops requesting pages", func(t *testing.T) {
+			if calls := driver.CallCount(path); calls != 2 {
+				t.Errorf("Expected 2 calls, got %d", calls)
+			}
+		})
+	})
+}
+
+func TestFind(t *testing.T) {
+	t.Run("when the blueprint exists", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "blueprints/1", nil, `{"blueprint" : {
+			"id" : "1",
+			"name" : "staging",
+			"data" : {
+				"app_instances" : [{"name" : "app", "flavor" : "m5.large", "volume_size" : 25}],
+				"db_master" : [{"name" : "db", "flavor" : "m5.xlarge", "volume_size" : 100, "volume_iops" : 3000}],
+				"utils" : [{"name" : "redis", "flavor" : "m5.large"}]
+			}
+		}}`)
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is populated", func(t *testing.T) {
+			if result == nil || result.Name != "staging" {
+				t.Fatalf("Expected blueprint 'staging'")
+			}
+		})
+
+		t.Run("it describes the instance layout", func(t *testing.T) {
+			if result.Data == nil || len(result.Data.Instances()) != 3 {
+				t.Fatalf("Expected 3 instances")
+			}
+
+			master := result.Data.DBMaster[0]
+			if master.Flavor != "m5.xlarge" || master.VolumeSize != 100 || master.VolumeIOPS != 3000 {
+				t.Errorf("Unexpected database master %+v", master)
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "blueprints/1", nil, errors.New("nope"))
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+	})
+}
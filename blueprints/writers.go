@@ -0,0 +1,165 @@
+package blueprints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/requests"
+)
+
+// ErrMissingName is returned when a blueprint is created without a name
+var ErrMissingName = errors.New("A name is required to create a blueprint")
+
+// ErrMissingEnvironment is returned when the API accepts a clone but doesn't
+// describe the environment that it created
+var ErrMissingEnvironment = errors.New("The API response did not include an environment")
+
+// ErrNoChanges is returned when an update is requested without any changes
+var ErrNoChanges = errors.New("Changes are required to update a blueprint")
+
+// Writer provides an interface for the functions that change blueprints to
+// talk to the API
+type Writer interface {
+	Reader
+	Post(string, url.Values, []byte) ([]byte, error)
+	Put(string, url.Values, []byte) ([]byte, error)
+	Delete(string, url.Values) ([]byte, error)
+}
+
+// Changes models the aspects of a Blueprint that we are allowed to change
+type Changes struct {
+	Name string `json:"name,omitempty"`
+}
+
+// Create requests that a blueprint with the given name be made from the
+// current server layout of the given environment. If there are issues along
+// the way, a non-nil error is returned. Otherwise, the error is nil and the
+// created entity is returned.
+func Create(driver Writer, environment *environments.Entity, name string) (*Entity, error) {
+	if len(name) == 0 {
+		return nil, ErrMissingName
+	}
+
+	return write(driver.Post, environmentPath(environment), &Changes{Name: name})
+}
+
+// Update requests that a blueprint be updated on the API to match the
+// provided changes. If the changes are nil, the error is ErrNoChanges. If
+// there are issues along the way, a non-nil error is returned. Otherwise, the
+// error is nil and the returned entity contains the requested changes.
+func Update(driver Writer, blueprint *Entity, changes *Changes) (*Entity, error) {
+	if changes == nil {
+		return nil, ErrNoChanges
+	}
+
+	return write(driver.Put, "blueprints/"+blueprint.ID, changes)
+}
+
+// Delete requests that the given blueprint be removed. If there are issues
+// along the way, a non-nil error is returned.
+func Delete(driver Writer, blueprint *Entity) error {
+	_, err := driver.Delete("blueprints/"+blueprint.ID, nil)
+
+	return err
+}
+
+// Boot requests that the given environment be booted with the server layout
+// of the given blueprint. The environment must already exist and must not be
+// running; use Clone to boot a new one. If there are problems along the way,
+// a non-nil error is returned.
+// Otherwise, the error is nil and the async request that tracks the boot is
+// returned.
+func Boot(driver environments.Operator, blueprint *Entity, environment *environments.Entity) (*requests.Entity, error) {
+	return environments.Boot(driver, environment, environments.BootOptions{BlueprintID: blueprint.ID})
+}
+
+// BootAndWait boots the given environment from the given blueprint as Boot
+// does, then waits for the boot to finish within the given context as
+// requests.Wait does
+func BootAndWait(ctx context.Context, driver environments.Operator, blueprint *Entity, environment *environments.Entity, interval time.Duration, progress requests.Progress) (*requests.Entity, error) {
+	return environments.BootAndWait(ctx, driver, environment, environments.BootOptions{BlueprintID: blueprint.ID}, interval, progress)
+}
+
+// Clone creates a new environment matching the given spec in the given
+// account as environments.Create does, then boots it from the given blueprint
+// as Boot does. If the environment is created but can't be booted, it is
+// returned along with the error so that the caller can retry the boot or
+// clean up. Otherwise, the new environment and the async request that tracks
+// its boot are returned.
+func Clone(driver environments.Operator, blueprint *Entity, account *accounts.Entity, spec *environments.Spec) (*environments.Entity, *requests.Entity, error) {
+	environment, err := environments.Create(driver, account, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if environment == nil {
+		return nil, nil, ErrMissingEnvironment
+	}
+
+	request, err := Boot(driver, blueprint, environment)
+	if err != nil {
+		return environment, nil, err
+	}
+
+	return environment, request, nil
+}
+
+// CloneAndWait clones an environment from the given blueprint as Clone does,
+// then waits for the boot to finish within the given context as
+// requests.Wait does
+func CloneAndWait(ctx context.Context, driver environments.Operator, blueprint *Entity, account *accounts.Entity, spec *environments.Spec, interval time.Duration, progress requests.Progress) (*environments.Entity, *requests.Entity, error) {
+	environment, request, err := Clone(driver, blueprint, account, spec)
+	if err != nil {
+		return environment, nil, err
+	}
+
+	finished, err := requests.Wait(ctx, driver, request, interval, progress)
+
+	return environment, finished, err
+}
+
+func write(send func(string, url.Values, []byte) ([]byte, error), path string, changes *Changes) (*Entity, error) {
+	wrappedChanges := struct {
+		Blueprint *Changes `json:"blueprint,omitempty"`
+	}{
+		Blueprint: changes,
+	}
+
+	data, err := json.Marshal(&wrappedChanges)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := send(path, nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(response)
+}
+
+func environmentPath(environment *environments.Entity) string {
+	pathParts := []string{"environments", environment.ID, "blueprints"}
+
+	return strings.Join(pathParts, "/")
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,290 @@
+package blueprints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+)
+
+func sentName(driver *maurytest.Driver) string {
+	wrapper := struct {
+		Blueprint *Changes `json:"blueprint"`
+	}{}
+
+	calls := driver.Calls()
+	if len(calls) == 0 {
+		return ""
+	}
+
+	json.Unmarshal(calls[0].Data, &wrapper)
+	if wrapper.Blueprint == nil {
+		return ""
+	}
+
+	return wrapper.Blueprint.Name
+}
+
+func TestCreate(t *testing.T) {
+	environment := &environments.Entity{ID: "2"}
+
+	t.Run("when a name is given", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/blueprints", nil, `{"blueprint" : {"id" : "1", "name" : "staging"}}`)
+
+		blueprint, err := Create(driver, environment, "staging")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the created blueprint", func(t *testing.T) {
+			if blueprint == nil || blueprint.ID != "1" {
+				t.Errorf("Expected blueprint 1")
+			}
+		})
+
+		t.Run("it sends the name", func(t *testing.T) {
+			if sentName(driver) != "staging" {
+				t.Errorf("Expected staging, got '%s'", sentName(driver))
+			}
+		})
+	})
+
+	t.Run("when no name is given", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := Create(driver, environment, "")
+
+		t.Run("the error is ErrMissingName", func(t *testing.T) {
+			if err != ErrMissingName {
+				t.Errorf("Expected ErrMissingName, got %v", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environments/2/blueprints")
+		})
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("when the API accepts the changes", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("PUT", "blueprints/1", nil, `{"blueprint" : {"id" : "1", "name" : "production"}}`)
+
+		blueprint, err := Update(driver, &Entity{ID: "1"}, &Changes{Name: "production"})
+
+		t.Run("it returns the updated blueprint", func(t *testing.T) {
+			if err != nil || blueprint == nil || blueprint.Name != "production" {
+				t.Errorf("Expected blueprint 'production', got error %v", err)
+			}
+		})
+
+		t.Run("it sends the changes", func(t *testing.T) {
+			if sentName(driver) != "production" {
+				t.Errorf("Expected production, got '%s'", sentName(driver))
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("PUT", "blueprints/1", nil, errors.New("nope"))
+
+		blueprint, err := Update(driver, &Entity{ID: "1"}, &Changes{Name: "production"})
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || blueprint != nil {
+				t.Errorf("Expected an error and no entity")
+			}
+		})
+	})
+
+	t.Run("when there are no changes", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		blueprint, err := Update(driver, &Entity{ID: "1"}, nil)
+
+		t.Run("the error is ErrNoChanges", func(t *testing.T) {
+			if err != ErrNoChanges || blueprint != nil {
+				t.Errorf("Expected ErrNoChanges and no entity, got %v", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "blueprints/1")
+		})
+	})
+}
+
+func TestDelete(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("DELETE", "blueprints/1", nil, ``)
+
+	t.Run("it deletes the blueprint", func(t *testing.T) {
+		if err := Delete(driver, &Entity{ID: "1"}); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+}
+
+func TestBoot(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("POST", "environments/3/boot", nil, `{"request" : {"id" : "5"}}`)
+
+	request, err := Boot(driver, &Entity{ID: "1"}, &environments.Entity{ID: "3"})
+
+	t.Run("it has no error", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it returns the async request", func(t *testing.T) {
+		if request == nil || request.ID != "5" {
+			t.Errorf("Expected request 5")
+		}
+	})
+
+	t.Run("it boots from the blueprint", func(t *testing.T) {
+		wrapper := struct {
+			ClusterConfiguration map[string]interface{} `json:"cluster_configuration"`
+		}{}
+
+		json.Unmarshal(driver.Calls()[0].Data, &wrapper)
+
+		if wrapper.ClusterConfiguration["blueprint_id"] != "1" {
+			t.Errorf("Expected blueprint 1, got %v", wrapper.ClusterConfiguration["blueprint_id"])
+		}
+	})
+}
+
+func TestClone(t *testing.T) {
+	blueprint := &Entity{ID: "1"}
+	account := &accounts.Entity{ID: "4"}
+	spec := &environments.Spec{Name: "staging-2", FrameworkEnv: "staging"}
+
+	t.Run("when the environment is created and booted", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "accounts/4/environments", nil, `{"environment" : {"id" : "3", "name" : "staging-2"}}`)
+		driver.Respond("POST", "environments/3/boot", nil, `{"request" : {"id" : "5"}}`)
+
+		environment, request, err := Clone(driver, blueprint, account, spec)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the new environment", func(t *testing.T) {
+			if environment == nil || environment.ID != "3" {
+				t.Errorf("Expected environment 3")
+			}
+		})
+
+		t.Run("it returns the async request for the boot", func(t *testing.T) {
+			if request == nil || request.ID != "5" {
+				t.Errorf("Expected request 5")
+			}
+		})
+
+		t.Run("it boots the new environment from the blueprint", func(t *testing.T) {
+			calls := driver.Calls()
+			if len(calls) != 2 || calls[1].Path != "environments/3/boot" {
+				t.Fatalf("Expected the environment to be created and then booted")
+			}
+
+			wrapper := struct {
+				ClusterConfiguration map[string]interface{} `json:"cluster_configuration"`
+			}{}
+
+			json.Unmarshal(calls[1].Data, &wrapper)
+
+			if wrapper.ClusterConfiguration["blueprint_id"] != "1" {
+				t.Errorf("Expected blueprint 1, got %v", wrapper.ClusterConfiguration["blueprint_id"])
+			}
+		})
+	})
+
+	t.Run("when the environment can't be created", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("POST", "accounts/4/environments", nil, errors.New("nope"))
+
+		environment, request, err := Clone(driver, blueprint, account, spec)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || environment != nil || request != nil {
+				t.Errorf("Expected an error and nothing else")
+			}
+		})
+
+		t.Run("it does not boot anything", func(t *testing.T) {
+			if len(driver.Calls()) != 1 {
+				t.Errorf("Expected only the create call")
+			}
+		})
+	})
+
+	t.Run("when the new environment can't be booted", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "accounts/4/environments", nil, `{"environment" : {"id" : "3", "name" : "staging-2"}}`)
+		driver.Fail("POST", "environments/3/boot", nil, errors.New("nope"))
+
+		environment, request, err := Clone(driver, blueprint, account, spec)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || request != nil {
+				t.Errorf("Expected an error and no request")
+			}
+		})
+
+		t.Run("it returns the new environment for cleanup", func(t *testing.T) {
+			if environment == nil || environment.ID != "3" {
+				t.Errorf("Expected environment 3")
+			}
+		})
+	})
+
+	t.Run("when the API doesn't describe the new environment", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "accounts/4/environments", nil, `{}`)
+
+		_, _, err := Clone(driver, blueprint, account, spec)
+
+		t.Run("the error is ErrMissingEnvironment", func(t *testing.T) {
+			if err != ErrMissingEnvironment {
+				t.Errorf("Expected ErrMissingEnvironment, got %v", err)
+			}
+		})
+	})
+}
+
+func TestCloneAndWait(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("POST", "accounts/4/environments", nil, `{"environment" : {"id" : "3", "name" : "staging-2"}}`)
+	driver.Respond("POST", "environments/3/boot", nil, `{"request" : {"id" : "5"}}`)
+	driver.Respond("GET", "requests/5", nil, `{"request" : {"id" : "5", "successful" : true, "finished_at" : "2018-01-01T00:00:00Z"}}`)
+
+	environment, request, err := CloneAndWait(context.Background(), driver, &Entity{ID: "1"}, &accounts.Entity{ID: "4"}, &environments.Spec{Name: "staging-2"}, time.Millisecond, nil)
+
+	t.Run("it waits for the boot to finish", func(t *testing.T) {
+		if err != nil || request == nil || !request.Finished() {
+			t.Errorf("Expected a finished request, got error %v", err)
+		}
+	})
+
+	t.Run("it returns the new environment", func(t *testing.T) {
+		if environment == nil || environment.ID != "3" {
+			t.Errorf("Expected environment 3")
+		}
+	})
+}
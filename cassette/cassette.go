@@ -0,0 +1,262 @@
+// Package cassette provides an http.RoundTripper that records interactions
+// with the Engine Yard API to a file and replays them later, so that tests
+// can be run against real API responses without network access
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/ess/maury/client"
+)
+
+// Mode determines whether a Recorder talks to the upstream API or replays
+// interactions from its cassette
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette file and never talks to
+	// the upstream API
+	ModeReplay Mode = iota
+
+	// ModeRecord passes requests along to the upstream API and records each
+	// interaction to the cassette
+	ModeRecord
+)
+
+// Redacted is the value that replaces secrets in recorded interactions
+const Redacted = client.Redacted
+
+// Request is the recorded form of an API request
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is the recorded form of an API response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a recorded request/response pair
+type Interaction struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+// UnmatchedRequestError is returned when a Recorder in ModeReplay receives a
+// request that is not on its cassette
+type UnmatchedRequestError struct {
+	Request *Request
+}
+
+func (err *UnmatchedRequestError) Error() string {
+	return fmt.Sprintf(
+		"cassette: no recorded interaction matches %s /%s?%s %s",
+		err.Request.Method,
+		err.Request.Path,
+		err.Request.Query,
+		err.Request.Body,
+	)
+}
+
+// Recorder is an http.RoundTripper that records or replays API interactions.
+// It can be used with a client.Driver via client.WithTransport.
+type Recorder struct {
+	mode         Mode
+	path         string
+	transport    http.RoundTripper
+	lock         sync.Mutex
+	interactions []*Interaction
+	played       []bool
+}
+
+// New returns a Recorder for the cassette at the given path. In ModeReplay,
+// the cassette is loaded immediately, and an error is returned if it cannot
+// be read. In ModeRecord, requests are sent via the given transport, or
+// http.DefaultTransport if it is nil.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	recorder := &Recorder{mode: mode, path: path, transport: transport}
+
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(data, &recorder.interactions)
+		if err != nil {
+			return nil, err
+		}
+
+		recorder.played = make([]bool, len(recorder.interactions))
+	}
+
+	return recorder, nil
+}
+
+// RoundTrip handles a single HTTP request, either by sending it upstream and
+// recording the result, or by replaying a matching recorded interaction. In
+// ModeReplay, a request that doesn't match any recorded interaction results in
+// an *UnmatchedRequestError.
+func (recorder *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if recorder.mode == ModeReplay {
+		return recorder.replay(request, recorded)
+	}
+
+	return recorder.record(request, recorded)
+}
+
+// Save writes the recorded interactions to the cassette file. It does nothing
+// in ModeReplay.
+func (recorder *Recorder) Save() error {
+	if recorder.mode == ModeReplay {
+		return nil
+	}
+
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	data, err := json.MarshalIndent(recorder.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(recorder.path, data, os.FileMode(0644))
+}
+
+func (recorder *Recorder) record(request *http.Request, recorded *Request) (*http.Response, error) {
+	response, err := recorder.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	recorder.interactions = append(
+		recorder.interactions,
+		&Interaction{
+			Request: recorded,
+			Response: &Response{
+				StatusCode: response.StatusCode,
+				Header:     response.Header,
+				Body:       string(client.RedactBody(body)),
+			},
+		},
+	)
+
+	return response, nil
+}
+
+func (recorder *Recorder) replay(request *http.Request, recorded *Request) (*http.Response, error) {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	match := -1
+
+	// Prefer interactions that haven't been played yet so that repeated
+	// requests replay in the order that they were recorded, but fall back to
+	// the last matching interaction if they've all been used.
+	for index, interaction := range recorder.interactions {
+		if !matches(interaction.Request, recorded) {
+			continue
+		}
+
+		match = index
+
+		if !recorder.played[index] {
+			break
+		}
+	}
+
+	if match < 0 {
+		return nil, &UnmatchedRequestError{Request: recorded}
+	}
+
+	recorder.played[match] = true
+	interaction := recorder.interactions[match]
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        interaction.Response.Header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       request,
+	}, nil
+}
+
+func recordRequest(request *http.Request) (*Request, error) {
+	var body []byte
+
+	if request.Body != nil {
+		data, err := ioutil.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		body = data
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	return &Request{
+		Method: request.Method,
+		Path:   request.URL.Path,
+		Query:  request.URL.Query().Encode(),
+		Header: client.RedactHeader(request.Header),
+		Body:   string(client.RedactBody(body)),
+	}, nil
+}
+
+func matches(recorded *Request, candidate *Request) bool {
+	return recorded.Method == candidate.Method &&
+		recorded.Path == candidate.Path &&
+		recorded.Query == candidate.Query &&
+		recorded.Body == candidate.Body
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,127 @@
+package cassette
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/maurytest"
+	"github.com/ess/maury/users"
+)
+
+func TestRecorder(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "cassette")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "interactions.json")
+
+	server := maurytest.NewServer("sekrit")
+	server.AddAccount(&accounts.Entity{ID: "1", Name: "Sausages"})
+	server.AddUser(&users.Entity{ID: "bob", APIToken: "bobs-token"})
+	server.SetCurrentUser(&users.Entity{ID: "bob"})
+
+	t.Run("when recording", func(t *testing.T) {
+		recorder, _ := New(path, ModeRecord, nil)
+		driver, _ := client.New(server.URL, server.Token, client.WithTransport(recorder))
+
+		user, err := users.Current(driver)
+		accounts.Update(driver, &accounts.Entity{ID: "1"}, &accounts.Changes{Name: "Bratwurst"})
+
+		t.Run("the caller gets the real response", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if user.APIToken != "bobs-token" {
+				t.Errorf("Expected the unredacted token, got '%s'", user.APIToken)
+			}
+		})
+
+		err = recorder.Save()
+
+		t.Run("the cassette is saved", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		data, _ := ioutil.ReadFile(path)
+
+		t.Run("the token header is redacted", func(t *testing.T) {
+			if strings.Contains(string(data), "sekrit") {
+				t.Errorf("Expected the X-EY-TOKEN header to be redacted")
+			}
+		})
+
+		t.Run("the api_token field is redacted", func(t *testing.T) {
+			if strings.Contains(string(data), "bobs-token") {
+				t.Errorf("Expected the api_token field to be redacted")
+			}
+		})
+	})
+
+	server.Close()
+
+	t.Run("when replaying", func(t *testing.T) {
+		recorder, err := New(path, ModeReplay, nil)
+		if err != nil {
+			t.Fatalf("Expected to load the cassette, got %s", err)
+		}
+
+		driver, _ := client.New(server.URL, "some-other-token", client.WithTransport(recorder))
+
+		t.Run("and the request was recorded", func(t *testing.T) {
+			user, err := users.Current(driver)
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if user.ID != "bob" {
+				t.Errorf("Expected bob, got '%s'", user.ID)
+			}
+		})
+
+		t.Run("and the request body matches", func(t *testing.T) {
+			account, err := accounts.Update(driver, &accounts.Entity{ID: "1"}, &accounts.Changes{Name: "Bratwurst"})
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if account.Name != "Bratwurst" {
+				t.Errorf("Expected Bratwurst, got '%s'", account.Name)
+			}
+		})
+
+		t.Run("and the request body differs", func(t *testing.T) {
+			_, err := accounts.Update(driver, &accounts.Entity{ID: "1"}, &accounts.Changes{Name: "Kielbasa"})
+
+			if err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+				t.Errorf("Expected an unmatched request error, got %v", err)
+			}
+		})
+
+		t.Run("and the request was never recorded", func(t *testing.T) {
+			_, err := accounts.Find(driver, "1")
+
+			if err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+				t.Errorf("Expected an unmatched request error, got %v", err)
+			}
+		})
+	})
+
+	t.Run("when the cassette does not exist", func(t *testing.T) {
+		_, err := New(filepath.Join(dir, "missing.json"), ModeReplay, nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
@@ -0,0 +1,333 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Authenticator knows how to add credentials to a request bound for the
+// upstream API
+type Authenticator interface {
+	Authenticate(*http.Request) error
+}
+
+// Refresher is implemented by authenticators whose credentials can go stale.
+// When the upstream API rejects a request as unauthorized, the Driver calls
+// Refresh and then retries the request once.
+type Refresher interface {
+	Refresh() error
+}
+
+// roundTripper is implemented by authenticators that talk to the upstream API
+// themselves. The Driver hands them its own round trip so that those requests
+// share its transport, middleware, logging and context.
+type roundTripper interface {
+	authenticateVia(request *http.Request, send RoundTrip) error
+	refreshVia(ctx context.Context, send RoundTrip) error
+}
+
+// StaticToken returns an Authenticator that sends the given token via the
+// X-EY-TOKEN header
+func StaticToken(token string) Authenticator {
+	return staticToken(token)
+}
+
+type staticToken string
+
+func (token staticToken) Authenticate(request *http.Request) error {
+	request.Header.Set("X-EY-TOKEN", string(token))
+	return nil
+}
+
+// EnvToken returns an Authenticator that reads its token from the given
+// environment variable every time that a request is made, so that changes to
+// the variable are picked up without creating a new Driver
+func EnvToken(variable string) Authenticator {
+	return envToken(variable)
+}
+
+type envToken string
+
+func (variable envToken) Authenticate(request *http.Request) error {
+	token := os.Getenv(string(variable))
+	if len(token) == 0 {
+		return errors.New("The environment variable " + string(variable) + " is empty")
+	}
+
+	request.Header.Set("X-EY-TOKEN", token)
+	return nil
+}
+
+// FileToken is an Authenticator that reads its token from a file the first
+// time that it is needed, and reads it again whenever the file changes
+type FileToken struct {
+	path     string
+	lock     sync.Mutex
+	token    string
+	modified time.Time
+}
+
+// NewFileToken returns a FileToken for the file at the given path
+func NewFileToken(path string) *FileToken {
+	return &FileToken{path: path}
+}
+
+// Authenticate sends the token from the file via the X-EY-TOKEN header
+func (auth *FileToken) Authenticate(request *http.Request) error {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	info, err := os.Stat(auth.path)
+	if err != nil {
+		return err
+	}
+
+	if len(auth.token) == 0 || !info.ModTime().Equal(auth.modified) {
+		err = auth.load(info.ModTime())
+		if err != nil {
+			return err
+		}
+	}
+
+	request.Header.Set("X-EY-TOKEN", auth.token)
+	return nil
+}
+
+// Refresh reads the token from the file again
+func (auth *FileToken) Refresh() error {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	info, err := os.Stat(auth.path)
+	if err != nil {
+		return err
+	}
+
+	return auth.load(info.ModTime())
+}
+
+func (auth *FileToken) load(modified time.Time) error {
+	data, err := ioutil.ReadFile(auth.path)
+	if err != nil {
+		return err
+	}
+
+	token := strings.TrimSpace(string(data))
+	if len(token) == 0 {
+		return errors.New("The token file " + auth.path + " is empty")
+	}
+
+	auth.token = token
+	auth.modified = modified
+
+	return nil
+}
+
+// BearerToken is an access token for use with the Authorization header
+type BearerToken struct {
+	AccessToken string
+
+	// Expiry is the time at which the token stops working. A zero Expiry means
+	// that the token does not expire.
+	Expiry time.Time
+}
+
+func (token *BearerToken) expired() bool {
+	if token.Expiry.IsZero() {
+		return false
+	}
+
+	// Leave a little headroom so that a token doesn't expire mid-request
+	return time.Now().Add(10 * time.Second).After(token.Expiry)
+}
+
+// TokenSource provides bearer tokens, such as those issued by an OAuth2
+// provider. Each call should return a fresh token.
+type TokenSource interface {
+	Token() (*BearerToken, error)
+}
+
+// TokenSourceFunc is an adapter that allows a plain function to be used as a
+// TokenSource
+type TokenSourceFunc func() (*BearerToken, error)
+
+// Token calls the underlying function
+func (source TokenSourceFunc) Token() (*BearerToken, error) {
+	return source()
+}
+
+// Bearer is an Authenticator that sends tokens from a TokenSource via the
+// Authorization header. Tokens are cached until they expire or the upstream
+// API rejects them.
+type Bearer struct {
+	source  TokenSource
+	lock    sync.Mutex
+	current *BearerToken
+}
+
+// NewBearer returns a Bearer for the given TokenSource
+func NewBearer(source TokenSource) *Bearer {
+	return &Bearer{source: source}
+}
+
+// Authenticate sends the current token via the Authorization header, fetching
+// a new one from the source if there is no current token or it has expired
+func (auth *Bearer) Authenticate(request *http.Request) error {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	if auth.current == nil || auth.current.expired() {
+		err := auth.fetch()
+		if err != nil {
+			return err
+		}
+	}
+
+	request.Header.Set("Authorization", "Bearer "+auth.current.AccessToken)
+	return nil
+}
+
+// Refresh fetches a new token from the source
+func (auth *Bearer) Refresh() error {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	return auth.fetch()
+}
+
+func (auth *Bearer) fetch() error {
+	token, err := auth.source.Token()
+	if err != nil {
+		return err
+	}
+
+	if token == nil || len(token.AccessToken) == 0 {
+		return errors.New("The token source returned an empty token")
+	}
+
+	auth.current = token
+	return nil
+}
+
+// Login is an Authenticator that exchanges an email address and password for
+// an API token the first time that it is needed, then sends that token via the
+// X-EY-TOKEN header. When used by a Driver, the exchange goes through the
+// Driver's transport, middleware and context.
+type Login struct {
+	baseURL  string
+	email    string
+	password string
+	raw      *http.Client
+	lock     sync.Mutex
+	token    string
+}
+
+// NewLogin returns a Login that authenticates against the API at the given
+// base URL with the given email address and password
+func NewLogin(baseURL string, email string, password string) *Login {
+	return &Login{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		email:    email,
+		password: password,
+		raw:      &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+// Authenticate sends the token obtained by logging in via the X-EY-TOKEN
+// header, logging in first if necessary
+func (auth *Login) Authenticate(request *http.Request) error {
+	return auth.authenticateVia(request, auth.raw.Do)
+}
+
+// Refresh logs in again to obtain a new token
+func (auth *Login) Refresh() error {
+	return auth.refreshVia(context.Background(), auth.raw.Do)
+}
+
+func (auth *Login) authenticateVia(request *http.Request, send RoundTrip) error {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	if len(auth.token) == 0 {
+		err := auth.login(request.Context(), send)
+		if err != nil {
+			return err
+		}
+	}
+
+	request.Header.Set("X-EY-TOKEN", auth.token)
+	return nil
+}
+
+func (auth *Login) refreshVia(ctx context.Context, send RoundTrip) error {
+	auth.lock.Lock()
+	defer auth.lock.Unlock()
+
+	return auth.login(ctx, send)
+}
+
+func (auth *Login) login(ctx context.Context, send RoundTrip) error {
+	request, err := http.NewRequest("POST", auth.baseURL+"/tokens", nil)
+	if err != nil {
+		return err
+	}
+
+	request = request.WithContext(ctx)
+
+	request.SetBasicAuth(auth.email, auth.password)
+	request.Header.Add("Accept", "application/vnd.engineyard.v3+json")
+	request.Header.Add("User-Agent", "maury-go/0.1.0 (https://github.com/ess/maury)")
+
+	response, err := send(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode > 299 {
+		return &APIError{StatusCode: response.StatusCode, Body: body}
+	}
+
+	wrapper := struct {
+		APIToken string `json:"api_token"`
+	}{}
+
+	err = json.Unmarshal(body, &wrapper)
+	if err != nil {
+		return err
+	}
+
+	if len(wrapper.APIToken) == 0 {
+		return errors.New("The upstream API did not return a token")
+	}
+
+	auth.token = wrapper.APIToken
+	return nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
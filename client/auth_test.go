@@ -0,0 +1,310 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// upstream is a fake API that only accepts the given credentials, keeping
+// track of the credentials that it has seen
+type upstream struct {
+	*httptest.Server
+	token  string
+	bearer string
+	seen   []string
+}
+
+func newUpstream(token string, bearer string) *upstream {
+	u := &upstream{token: token, bearer: bearer}
+
+	u.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/tokens" {
+			email, password, ok := r.BasicAuth()
+			if !ok || email != "bob@example.com" || password != "hunter2" {
+				w.WriteHeader(401)
+				return
+			}
+
+			w.Write([]byte(`{"api_token" : "` + u.token + `"}`))
+			return
+		}
+
+		credential := r.Header.Get("X-EY-TOKEN") + r.Header.Get("Authorization")
+		u.seen = append(u.seen, credential)
+
+		if credential != u.token && credential != "Bearer "+u.bearer {
+			w.WriteHeader(401)
+			return
+		}
+
+		w.Write([]byte(`{"sausages" : "gold"}`))
+	}))
+
+	return u
+}
+
+// roundTripFunc lets a plain function stand in for an http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return fn(request)
+}
+
+func TestStaticToken(t *testing.T) {
+	api := newUpstream("sekrit", "")
+	defer api.Close()
+
+	driver, _ := New(api.URL, "sekrit")
+	_, err := driver.Get("sausages", nil)
+
+	t.Run("it sends the token", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+}
+
+func TestEnvToken(t *testing.T) {
+	api := newUpstream("sekrit", "")
+	defer api.Close()
+
+	os.Setenv("MAURY_TEST_TOKEN", "nope")
+	defer os.Unsetenv("MAURY_TEST_TOKEN")
+
+	driver, _ := New(api.URL, "", WithAuthenticator(EnvToken("MAURY_TEST_TOKEN")))
+
+	_, first := driver.Get("sausages", nil)
+
+	os.Setenv("MAURY_TEST_TOKEN", "sekrit")
+
+	_, second := driver.Get("sausages", nil)
+
+	t.Run("it reads the variable for every request", func(t *testing.T) {
+		if first == nil {
+			t.Errorf("Expected the first request to be rejected")
+		}
+
+		if second != nil {
+			t.Errorf("Expected the second request to succeed, got %s", second)
+		}
+	})
+}
+
+func TestFileToken(t *testing.T) {
+	api := newUpstream("sekrit", "")
+	defer api.Close()
+
+	dir, _ := ioutil.TempDir("", "client")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token")
+	ioutil.WriteFile(path, []byte("stale\n"), 0600)
+
+	driver, _ := New(api.URL, "", WithAuthenticator(NewFileToken(path)))
+
+	t.Run("when the token is wrong", func(t *testing.T) {
+		_, err := driver.Get("sausages", nil)
+
+		if err == nil {
+			t.Errorf("Expected the request to be rejected")
+		}
+	})
+
+	t.Run("when the file is updated", func(t *testing.T) {
+		ioutil.WriteFile(path, []byte("sekrit\n"), 0600)
+
+		// Make sure that the change is visible even on filesystems with coarse
+		// modification times
+		later := time.Now().Add(time.Minute)
+		os.Chtimes(path, later, later)
+
+		_, err := driver.Get("sausages", nil)
+
+		if err != nil {
+			t.Errorf("Expected the new token to be used, got %s", err)
+		}
+	})
+
+	t.Run("when the file is missing", func(t *testing.T) {
+		os.Remove(path)
+
+		_, err := driver.Get("sausages", nil)
+
+		if err == nil {
+			t.Errorf("Expected an error")
+		}
+	})
+}
+
+func TestBearer(t *testing.T) {
+	api := newUpstream("", "fresh")
+	defer api.Close()
+
+	t.Run("when the token is expired", func(t *testing.T) {
+		issued := []*BearerToken{
+			{AccessToken: "expired", Expiry: time.Now().Add(-time.Minute)},
+			{AccessToken: "fresh", Expiry: time.Now().Add(time.Hour)},
+		}
+
+		calls := 0
+		source := TokenSourceFunc(func() (*BearerToken, error) {
+			token := issued[calls]
+			calls++
+			return token, nil
+		})
+
+		auth := NewBearer(source)
+		driver, _ := New(api.URL, "", WithAuthenticator(auth))
+
+		_, err := driver.Get("sausages", nil)
+		_, again := driver.Get("sausages", nil)
+
+		t.Run("it fetches a new token", func(t *testing.T) {
+			if err != nil || again != nil {
+				t.Errorf("Expected both requests to succeed, got %v and %v", err, again)
+			}
+		})
+
+		t.Run("it caches the fresh token", func(t *testing.T) {
+			if calls != 2 {
+				t.Errorf("Expected 2 tokens to be issued, got %d", calls)
+			}
+		})
+	})
+
+	t.Run("when the API rejects the token", func(t *testing.T) {
+		issued := []string{"revoked", "fresh"}
+
+		calls := 0
+		source := TokenSourceFunc(func() (*BearerToken, error) {
+			token := issued[calls]
+			calls++
+			return &BearerToken{AccessToken: token}, nil
+		})
+
+		driver, _ := New(api.URL, "", WithAuthenticator(NewBearer(source)))
+
+		_, err := driver.Get("sausages", nil)
+
+		t.Run("it refreshes and retries", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected the retry to succeed, got %s", err)
+			}
+
+			if calls != 2 {
+				t.Errorf("Expected 2 tokens to be issued, got %d", calls)
+			}
+		})
+	})
+
+	t.Run("when the source fails", func(t *testing.T) {
+		source := TokenSourceFunc(func() (*BearerToken, error) {
+			return nil, errors.New("identity provider is down")
+		})
+
+		driver, _ := New(api.URL, "", WithAuthenticator(NewBearer(source)))
+
+		_, err := driver.Get("sausages", nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestLogin(t *testing.T) {
+	api := newUpstream("sekrit", "")
+	defer api.Close()
+
+	t.Run("when the credentials are good", func(t *testing.T) {
+		driver, _ := New(api.URL, "", WithAuthenticator(NewLogin(api.URL, "bob@example.com", "hunter2")))
+
+		_, err := driver.Get("sausages", nil)
+
+		t.Run("it exchanges them for a token", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+
+			if last := api.seen[len(api.seen)-1]; last != "sekrit" {
+				t.Errorf("Expected the exchanged token to be sent, got '%s'", last)
+			}
+		})
+	})
+
+	t.Run("when the credentials are bad", func(t *testing.T) {
+		driver, _ := New(api.URL, "", WithAuthenticator(NewLogin(api.URL, "bob@example.com", "password1")))
+
+		_, err := driver.Get("sausages", nil)
+
+		t.Run("the error is an APIError", func(t *testing.T) {
+			if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != 401 {
+				t.Errorf("Expected a 401 APIError, got %v", err)
+			}
+		})
+	})
+
+	t.Run("when the driver has a custom transport", func(t *testing.T) {
+		var paths []string
+
+		transport := roundTripFunc(func(request *http.Request) (*http.Response, error) {
+			paths = append(paths, request.URL.Path)
+
+			body := `{"sausages" : "gold"}`
+			if request.URL.Path == "/tokens" {
+				body = `{"api_token" : "sekrit"}`
+			}
+
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Request:    request,
+			}, nil
+		})
+
+		driver, _ := New(
+			"https://api.invalid",
+			"",
+			WithTransport(transport),
+			WithAuthenticator(NewLogin("https://api.invalid", "bob@example.com", "hunter2")),
+		)
+
+		_, err := driver.Get("sausages", nil)
+
+		t.Run("it logs in through the transport", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+
+			if len(paths) != 2 || paths[0] != "/tokens" {
+				t.Errorf("Expected the login to use the transport, got %v", paths)
+			}
+		})
+	})
+
+	t.Run("when the driver's context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		driver, _ := New(api.URL, "", WithAuthenticator(NewLogin(api.URL, "bob@example.com", "hunter2")))
+
+		_, err := driver.WithContext(ctx).Get("sausages", nil)
+
+		t.Run("the login is not sent", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
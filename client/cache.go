@@ -0,0 +1,250 @@
+package client
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache is an opt-in store for the responses to GET requests. Cached responses
+// are served without contacting the upstream API until they are older than
+// the cache's TTL, after which they are revalidated with If-None-Match and
+// If-Modified-Since. Responses are cached separately for each set of
+// credentials.
+type Cache struct {
+	ttl        time.Duration
+	maxEntries int
+	lock       sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type cacheEntry struct {
+	key      string
+	path     string
+	header   http.Header
+	body     []byte
+	storedAt time.Time
+}
+
+// NewCache returns a Cache that serves responses for the given TTL and holds
+// at most the given number of responses, evicting the least recently used
+// when it is full. A TTL of zero means that every response is revalidated
+// before it is used, and a maxEntries of zero means that the cache is
+// unbounded.
+func NewCache(ttl time.Duration, maxEntries int) *Cache {
+	return &Cache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Len returns the number of responses in the cache
+func (cache *Cache) Len() int {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	return cache.order.Len()
+}
+
+// Purge removes every response from the cache
+func (cache *Cache) Purge() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.entries = make(map[string]*list.Element)
+	cache.order.Init()
+}
+
+// Invalidate removes the cached responses for the resource at the given URL
+// path, for anything nested beneath it, and for everything that contains it.
+// That way, an action such as accounts/1/cancel also invalidates both
+// accounts/1 and the accounts collection.
+func (cache *Cache) Invalidate(resource string) {
+	resource = "/" + strings.Trim(resource, "/")
+
+	ancestors := make(map[string]bool)
+	for parent := path.Dir(resource); parent != "/"; parent = path.Dir(parent) {
+		ancestors[parent] = true
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	for key, element := range cache.entries {
+		entryPath := element.Value.(*cacheEntry).path
+
+		if entryPath == resource || ancestors[entryPath] || strings.HasPrefix(entryPath, resource+"/") {
+			cache.order.Remove(element)
+			delete(cache.entries, key)
+		}
+	}
+}
+
+func (cache *Cache) middleware(next RoundTrip) RoundTrip {
+	return func(request *http.Request) (*http.Response, error) {
+		if request.Method != "GET" {
+			response, err := next(request)
+
+			if err == nil && response.StatusCode < 300 {
+				cache.Invalidate(request.URL.Path)
+			}
+
+			return response, err
+		}
+
+		key := cacheKey(request)
+		entry := cache.lookup(key)
+
+		if entry != nil {
+			if time.Since(entry.storedAt) < cache.ttl {
+				return entry.response(request), nil
+			}
+
+			if etag := entry.header.Get("ETag"); len(etag) > 0 {
+				request.Header.Set("If-None-Match", etag)
+			}
+
+			if modified := entry.header.Get("Last-Modified"); len(modified) > 0 {
+				request.Header.Set("If-Modified-Since", modified)
+			}
+		}
+
+		response, err := next(request)
+		if err != nil {
+			return nil, err
+		}
+
+		if entry != nil && response.StatusCode == http.StatusNotModified {
+			response.Body.Close()
+			cache.touch(key)
+
+			return entry.response(request), nil
+		}
+
+		if response.StatusCode != http.StatusOK {
+			return response, nil
+		}
+
+		body, err := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		cache.store(&cacheEntry{
+			key:      key,
+			path:     request.URL.Path,
+			header:   copyHeader(response.Header),
+			body:     body,
+			storedAt: time.Now(),
+		})
+
+		return response, nil
+	}
+}
+
+// lookup returns a copy of the entry for the given key, so that the caller
+// can read it without holding the lock while touch updates the original
+func (cache *Cache) lookup(key string) *cacheEntry {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil
+	}
+
+	cache.order.MoveToFront(element)
+
+	copied := *element.Value.(*cacheEntry)
+
+	return &copied
+}
+
+func (cache *Cache) touch(key string) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if element, ok := cache.entries[key]; ok {
+		element.Value.(*cacheEntry).storedAt = time.Now()
+	}
+}
+
+func (cache *Cache) store(entry *cacheEntry) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if element, ok := cache.entries[entry.key]; ok {
+		cache.order.Remove(element)
+	}
+
+	cache.entries[entry.key] = cache.order.PushFront(entry)
+
+	for cache.maxEntries > 0 && cache.order.Len() > cache.maxEntries {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (entry *cacheEntry) response(request *http.Request) *http.Response {
+	header := copyHeader(entry.header)
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(entry.body)),
+		ContentLength: int64(len(entry.body)),
+		Request:       request,
+	}
+}
+
+// cacheKey identifies a request by its URL and a digest of its credentials, so
+// that responses are never shared between tokens and tokens are never held in
+// the cache
+func cacheKey(request *http.Request) string {
+	digest := sha256.Sum256([]byte(
+		request.Header.Get("X-EY-TOKEN") + "\n" + request.Header.Get("Authorization"),
+	))
+
+	return hex.EncodeToString(digest[:]) + " " + request.URL.String()
+}
+
+func copyHeader(original http.Header) http.Header {
+	copied := http.Header{}
+	for name, values := range original {
+		copied[name] = append([]string(nil), values...)
+	}
+
+	return copied
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,333 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type etagUpstream struct {
+	*httptest.Server
+	lock sync.Mutex
+	hits map[string]int
+	body string
+	etag string
+}
+
+func newETagUpstream() *etagUpstream {
+	upstream := &etagUpstream{
+		hits: make(map[string]int),
+		body: `{"sausages" : "gold"}`,
+		etag: `"v1"`,
+	}
+
+	upstream.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstream.lock.Lock()
+		defer upstream.lock.Unlock()
+
+		upstream.hits[r.Method+" "+r.URL.Path]++
+
+		if r.Method != "GET" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{}`))
+			return
+		}
+
+		if r.Header.Get("If-None-Match") == upstream.etag {
+			upstream.hits["304"]++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", upstream.etag)
+		w.Header().Set("Last-Modified", "Mon, 19 Oct 2026 10:00:00 GMT")
+		w.Write([]byte(upstream.body + r.Header.Get("X-EY-TOKEN")))
+	}))
+
+	return upstream
+}
+
+func (upstream *etagUpstream) count(key string) int {
+	upstream.lock.Lock()
+	defer upstream.lock.Unlock()
+
+	return upstream.hits[key]
+}
+
+func (upstream *etagUpstream) change(body string, etag string) {
+	upstream.lock.Lock()
+	defer upstream.lock.Unlock()
+
+	upstream.body = body
+	upstream.etag = etag
+}
+
+func TestWithCache(t *testing.T) {
+	t.Run("when a response is fresh", func(t *testing.T) {
+		api := newETagUpstream()
+		defer api.Close()
+
+		driver, _ := New(api.URL, "sekrit", WithCache(NewCache(time.Minute, 0)))
+		first, _ := driver.Get("sausages", nil)
+		second, err := driver.Get("sausages", nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is served from the cache", func(t *testing.T) {
+			if string(second) != string(first) {
+				t.Errorf("Expected '%s', got '%s'", string(first), string(second))
+			}
+
+			if hits := api.count("GET /sausages"); hits != 1 {
+				t.Errorf("Expected 1 upstream request, got %d", hits)
+			}
+		})
+	})
+
+	t.Run("when a response is stale", func(t *testing.T) {
+		api := newETagUpstream()
+		defer api.Close()
+
+		driver, _ := New(api.URL, "sekrit", WithCache(NewCache(0, 0)))
+		first, _ := driver.Get("sausages", nil)
+
+		t.Run("and it has not changed upstream", func(t *testing.T) {
+			second, err := driver.Get("sausages", nil)
+
+			t.Run("it has no error", func(t *testing.T) {
+				if err != nil {
+					t.Errorf("Expected no error, got %s", err)
+				}
+			})
+
+			t.Run("it is revalidated", func(t *testing.T) {
+				if responses := api.count("304"); responses != 1 {
+					t.Errorf("Expected 1 not modified response, got %d", responses)
+				}
+			})
+
+			t.Run("the cached body is used", func(t *testing.T) {
+				if string(second) != string(first) {
+					t.Errorf("Expected '%s', got '%s'", string(first), string(second))
+				}
+			})
+		})
+
+		t.Run("and it has changed upstream", func(t *testing.T) {
+			api.change(`{"sausages" : "silver"}`, `"v2"`)
+			third, _ := driver.Get("sausages", nil)
+
+			t.Run("the new body is used", func(t *testing.T) {
+				if string(third) != `{"sausages" : "silver"}sekrit` {
+					t.Errorf("Unexpected result '%s'", string(third))
+				}
+			})
+		})
+	})
+
+	t.Run("when drivers with different tokens share a cache", func(t *testing.T) {
+		api := newETagUpstream()
+		defer api.Close()
+
+		cache := NewCache(time.Minute, 0)
+		alice, _ := New(api.URL, "alice", WithCache(cache))
+		bob, _ := New(api.URL, "bob", WithCache(cache))
+
+		alice.Get("sausages", nil)
+		result, _ := bob.Get("sausages", nil)
+
+		t.Run("responses are not shared between them", func(t *testing.T) {
+			if string(result) != `{"sausages" : "gold"}bob` {
+				t.Errorf("Unexpected result '%s'", string(result))
+			}
+
+			if cache.Len() != 2 {
+				t.Errorf("Expected 2 cached responses, got %d", cache.Len())
+			}
+		})
+	})
+
+	t.Run("when the cache is full", func(t *testing.T) {
+		api := newETagUpstream()
+		defer api.Close()
+
+		cache := NewCache(time.Minute, 2)
+		driver, _ := New(api.URL, "sekrit", WithCache(cache))
+
+		driver.Get("sausages", nil)
+		driver.Get("bacon", nil)
+		driver.Get("sausages", nil)
+		driver.Get("eggs", nil)
+		driver.Get("bacon", nil)
+
+		t.Run("it holds no more than its maximum", func(t *testing.T) {
+			if cache.Len() != 2 {
+				t.Errorf("Expected 2 cached responses, got %d", cache.Len())
+			}
+		})
+
+		t.Run("the least recently used response is evicted", func(t *testing.T) {
+			if hits := api.count("GET /bacon"); hits != 2 {
+				t.Errorf("Expected 2 upstream requests for bacon, got %d", hits)
+			}
+
+			if hits := api.count("GET /sausages"); hits != 1 {
+				t.Errorf("Expected 1 upstream request for sausages, got %d", hits)
+			}
+		})
+	})
+
+	t.Run("when a resource is updated", func(t *testing.T) {
+		api := newETagUpstream()
+		defer api.Close()
+
+		driver, _ := New(api.URL, "sekrit", WithCache(NewCache(time.Minute, 0)))
+
+		driver.Get("accounts", nil)
+		driver.Get("accounts/1", nil)
+		driver.Get("accounts/2", nil)
+		driver.Put("accounts/1", nil, []byte(`{}`))
+
+		driver.Get("accounts", nil)
+		driver.Get("accounts/1", nil)
+		driver.Get("accounts/2", nil)
+
+		t.Run("the resource is invalidated", func(t *testing.T) {
+			if hits := api.count("GET /accounts/1"); hits != 2 {
+				t.Errorf("Expected 2 upstream requests, got %d", hits)
+			}
+		})
+
+		t.Run("its collection is invalidated", func(t *testing.T) {
+			if hits := api.count("GET /accounts"); hits != 2 {
+				t.Errorf("Expected 2 upstream requests, got %d", hits)
+			}
+		})
+
+		t.Run("its siblings are not invalidated", func(t *testing.T) {
+			if hits := api.count("GET /accounts/2"); hits != 1 {
+				t.Errorf("Expected 1 upstream request, got %d", hits)
+			}
+		})
+	})
+
+	t.Run("when an action is performed on a resource", func(t *testing.T) {
+		api := newETagUpstream()
+		defer api.Close()
+
+		driver, _ := New(api.URL, "sekrit", WithCache(NewCache(time.Minute, 0)))
+
+		driver.Get("accounts", nil)
+		driver.Get("accounts/1", nil)
+		driver.Post("accounts/1/cancel", nil, []byte(`{}`))
+
+		driver.Get("accounts", nil)
+		driver.Get("accounts/1", nil)
+
+		t.Run("the resource is invalidated", func(t *testing.T) {
+			if hits := api.count("GET /accounts/1"); hits != 2 {
+				t.Errorf("Expected 2 upstream requests, got %d", hits)
+			}
+		})
+
+		t.Run("its collection is invalidated", func(t *testing.T) {
+			if hits := api.count("GET /accounts"); hits != 2 {
+				t.Errorf("Expected 2 upstream requests, got %d", hits)
+			}
+		})
+	})
+
+	t.Run("when stale responses are revalidated concurrently", func(t *testing.T) {
+		// A slow transport without any locking of its own, so that nothing but
+		// the cache orders the goroutines while their requests overlap
+		transport := roundTripFunc(func(request *http.Request) (*http.Response, error) {
+			time.Sleep(time.Millisecond)
+
+			response := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": []string{`"v1"`}},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"sausages" : "gold"}`)),
+				Request:    request,
+			}
+
+			if request.Header.Get("If-None-Match") == `"v1"` {
+				response.StatusCode = http.StatusNotModified
+			}
+
+			return response, nil
+		})
+
+		driver, _ := New("https://api.invalid", "sekrit", WithTransport(transport), WithCache(NewCache(0, 0)))
+		driver.Get("sausages", nil)
+
+		var wg sync.WaitGroup
+		failures := make(chan error, 10)
+
+		for x := 0; x < 10; x++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				if _, err := driver.Get("sausages", nil); err != nil {
+					failures <- err
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(failures)
+
+		t.Run("every request succeeds", func(t *testing.T) {
+			for err := range failures {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	})
+
+	t.Run("when a resource is invalidated by hand", func(t *testing.T) {
+		api := newETagUpstream()
+		defer api.Close()
+
+		driver, _ := New(api.URL, "sekrit", WithCache(NewCache(time.Minute, 0)))
+
+		driver.Get("accounts/1", nil)
+		driver.Get("accounts/1/users", nil)
+		driver.Invalidate("accounts/1")
+		driver.Get("accounts/1", nil)
+		driver.Get("accounts/1/users", nil)
+
+		t.Run("it and its nested resources are fetched again", func(t *testing.T) {
+			if hits := api.count("GET /accounts/1"); hits != 2 {
+				t.Errorf("Expected 2 upstream requests, got %d", hits)
+			}
+
+			if hits := api.count("GET /accounts/1/users"); hits != 2 {
+				t.Errorf("Expected 2 upstream requests, got %d", hits)
+			}
+		})
+	})
+
+	t.Run("when no cache is configured", func(t *testing.T) {
+		api := newETagUpstream()
+		defer api.Close()
+
+		driver, _ := New(api.URL, "sekrit")
+		driver.Get("sausages", nil)
+		driver.Get("sausages", nil)
+
+		t.Run("every request reaches the upstream API", func(t *testing.T) {
+			if hits := api.count("GET /sausages"); hits != 2 {
+				t.Errorf("Expected 2 upstream requests, got %d", hits)
+			}
+		})
+	})
+}
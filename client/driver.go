@@ -4,7 +4,7 @@ package client
 
 import (
 	"bytes"
-	"fmt"
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -15,28 +15,78 @@ import (
 // Driver is an object that knows specifically how to interact with the
 // Engine Yard API at the HTTP level
 type Driver struct {
-	raw     *http.Client
-	baseURL url.URL
-	token   string
+	raw        *http.Client
+	baseURL    url.URL
+	auth       Authenticator
+	middleware []Middleware
+	logger     Logger
+	logBodies  bool
+	cache      *Cache
+	ctx        context.Context
 }
 
 // New takes a base URL for an Engine Yard API and a token, returning a Driver
-// that can be used to interact with the API in question.
-func New(baseURL string, token string) (*Driver, error) {
+// that can be used to interact with the API in question. Any options given are
+// applied to the Driver before it is returned. To authenticate by some means
+// other than a static token, pass an empty token and the WithAuthenticator
+// option. If the EY_DEBUG environment variable is set, the Driver logs its
+// traffic to stderr unless another logger is configured.
+func New(baseURL string, token string, options ...Option) (*Driver, error) {
 	url, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, err
 	}
 
 	d := &Driver{
-		&http.Client{Timeout: 20 * time.Second},
-		*url,
-		token,
+		raw:     &http.Client{Timeout: 20 * time.Second},
+		baseURL: *url,
+		auth:    StaticToken(token),
+		ctx:     context.Background(),
+	}
+
+	debugFromEnvironment(d)
+
+	for _, option := range options {
+		option(d)
 	}
 
 	return d, nil
 }
 
+// WithContext returns a copy of the Driver that makes all of its requests with
+// the given context, so that they can be cancelled and traced along with the
+// caller's work. The copy can be passed to any of the resource functions.
+func (driver *Driver) WithContext(ctx context.Context) *Driver {
+	copied := *driver
+	copied.ctx = ctx
+
+	return &copied
+}
+
+// BaseURL returns a copy of the URL that the driver resolves request paths
+// against
+func (driver *Driver) BaseURL() *url.URL {
+	copied := driver.baseURL
+
+	return &copied
+}
+
+// Invalidate removes the cached responses for the resource at the given path
+// if the Driver has a cache. Successful POST, PUT, PATCH and DELETE requests
+// do this automatically for the resources that they change.
+func (driver *Driver) Invalidate(path string) {
+	if driver.cache == nil {
+		return
+	}
+
+	requestURL, err := url.Parse(driver.constructRequestURL(path, nil))
+	if err != nil {
+		return
+	}
+
+	driver.cache.Invalidate(requestURL.Path)
+}
+
 // Get performs a GET operation for the given path and params against the
 // upstream API. it returns a byte array and an error.
 func (driver *Driver) Get(path string, params url.Values) ([]byte, error) {
@@ -68,7 +118,47 @@ func (driver *Driver) Delete(path string, params url.Values) ([]byte, error) {
 }
 
 func (driver *Driver) makeRequest(verb string, path string, params url.Values, data []byte) ([]byte, error) {
+	response, err := driver.send(verb, path, params, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the credentials have gone stale and the authenticator knows how to
+	// refresh them, give the request one more try with fresh credentials.
+	if refresher, ok := driver.auth.(Refresher); ok && response.StatusCode == http.StatusUnauthorized {
+		response.Body.Close()
+
+		if tripper, ok := driver.auth.(roundTripper); ok {
+			err = tripper.refreshVia(driver.ctx, driver.roundTrip())
+		} else {
+			err = refresher.Refresh()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		response, err = driver.send(verb, path, params, data)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode > 299 {
+		return nil, &APIError{StatusCode: response.StatusCode, Body: body}
+	}
+
+	return body, nil
+}
+
+func (driver *Driver) send(verb string, path string, params url.Values, data []byte) (*http.Response, error) {
 	request, err := http.NewRequest(
 		verb,
 		driver.constructRequestURL(path, params),
@@ -79,31 +169,41 @@ func (driver *Driver) makeRequest(verb string, path string, params url.Values, d
 		return nil, err
 	}
 
-	request.Header.Add("X-EY-TOKEN", driver.token)
+	request = request.WithContext(driver.ctx)
+
 	request.Header.Add("Accept", "application/vnd.engineyard.v3+json")
 	request.Header.Add("Content-Type", "application/json")
 	request.Header.Add("User-Agent", "maury-go/0.1.0 (https://github.com/ess/maury)")
 
-	response, err := driver.raw.Do(request)
-	if err != nil {
-		return nil, err
+	send := driver.roundTrip()
+
+	if tripper, ok := driver.auth.(roundTripper); ok {
+		err = tripper.authenticateVia(request, send)
+	} else {
+		err = driver.auth.Authenticate(request)
 	}
 
-	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
 		return nil, err
 	}
 
-	defer response.Body.Close()
+	return send(request)
+}
 
-	if response.StatusCode > 299 {
-		return nil, fmt.Errorf(
-			"The upstream API returned the following status: %d",
-			response.StatusCode,
-		)
+// roundTrip returns the driver's transport wrapped in its logging, caching
+// and middleware
+func (driver *Driver) roundTrip() RoundTrip {
+	final := RoundTrip(driver.raw.Do)
+	if driver.logger != nil {
+		final = Logging(driver.logger, driver.logBodies)(final)
 	}
 
-	return body, nil
+	middleware := driver.middleware
+	if driver.cache != nil {
+		middleware = append([]Middleware{driver.cache.middleware}, middleware...)
+	}
+
+	return chain(final, middleware)
 }
 
 func (driver *Driver) constructRequestURL(path string, params url.Values) string {
@@ -105,6 +105,23 @@ func TestDriver_Get(t *testing.T) {
 					}
 				})
 
+			t.Run(
+				"the error carries the upstream response",
+				func(t *testing.T) {
+					apiErr, ok := err.(*APIError)
+					if !ok {
+						t.Fatalf("Expected an *APIError, got %T", err)
+					}
+
+					if apiErr.StatusCode != 500 {
+						t.Errorf("Expected status 500, got %d", apiErr.StatusCode)
+					}
+
+					if string(apiErr.Body) != "Drop your weapon. You have 20 seconds to comply." {
+						t.Errorf("Expected the response body, got '%s'", string(apiErr.Body))
+					}
+				})
+
 		})
 
 	t.Run(
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"fmt"
+)
+
+// APIError is returned when the upstream API responds to a request with an
+// unsuccessful status. The body of the response is retained so that callers
+// can inspect the reason for the failure.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (err *APIError) Error() string {
+	return fmt.Sprintf(
+		"The upstream API returned the following status: %d",
+		err.StatusCode,
+	)
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Logger is the interface through which a Driver reports on its traffic. It is
+// satisfied by *slog.Logger, as well as by many other structured loggers. The
+// args are alternating keys and values.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+}
+
+// Logging returns a Middleware that logs every request that passes through it
+// with its method, URL, status, duration and attempt number. If bodies is
+// true, request and response bodies are logged as well. Credentials are
+// redacted from everything that is logged.
+//
+// Drivers configured via WithLogger or EY_DEBUG already log at the innermost
+// point of their middleware chain, so that every retry attempt is logged.
+func Logging(logger Logger, bodies bool) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+			args := []interface{}{
+				"method", request.Method,
+				"url", request.URL.String(),
+				"attempt", Attempt(request),
+			}
+
+			if bodies && request.Body != nil {
+				body, err := ioutil.ReadAll(request.Body)
+				request.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+
+				request.Body = ioutil.NopCloser(bytes.NewReader(body))
+				args = append(args, "request_body", string(RedactBody(body)))
+			}
+
+			if bodies {
+				args = append(args, "request_header", RedactHeader(request.Header))
+			}
+
+			started := time.Now()
+			response, err := next(request)
+			args = append(args, "duration", time.Since(started))
+
+			if err != nil {
+				logger.Debug("maury request failed", append(args, "error", err.Error())...)
+				return nil, err
+			}
+
+			args = append(args, "status", response.StatusCode)
+
+			if bodies {
+				body, rerr := ioutil.ReadAll(response.Body)
+				response.Body.Close()
+				if rerr != nil {
+					return nil, rerr
+				}
+
+				response.Body = ioutil.NopCloser(bytes.NewReader(body))
+				args = append(args, "response_body", string(RedactBody(body)))
+			}
+
+			logger.Debug("maury request", args...)
+
+			return response, nil
+		}
+	}
+}
+
+// debugFromEnvironment enables logging to stderr for the given Driver if the
+// EY_DEBUG environment variable is set. If it is set to "bodies", request and
+// response bodies are logged as well.
+func debugFromEnvironment(driver *Driver) {
+	setting := os.Getenv("EY_DEBUG")
+	if len(setting) == 0 {
+		return
+	}
+
+	driver.logger = NewWriterLogger(os.Stderr)
+	driver.logBodies = setting == "bodies"
+}
+
+// NewWriterLogger returns a Logger that writes each message and its args as a
+// line of key=value pairs to the given writer
+func NewWriterLogger(writer io.Writer) Logger {
+	return &writerLogger{log.New(writer, "", log.LstdFlags)}
+}
+
+type writerLogger struct {
+	raw *log.Logger
+}
+
+func (logger *writerLogger) Debug(msg string, args ...interface{}) {
+	fields := []string{msg}
+
+	for index := 0; index+1 < len(args); index += 2 {
+		value := fmt.Sprint(args[index+1])
+		if strings.ContainsAny(value, " \t\n\"") {
+			value = fmt.Sprintf("%q", value)
+		}
+
+		fields = append(fields, fmt.Sprintf("%v=%s", args[index], value))
+	}
+
+	logger.raw.Println(strings.Join(fields, " "))
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type entry struct {
+	msg    string
+	fields map[string]interface{}
+}
+
+type memoryLogger struct {
+	entries []*entry
+}
+
+func (logger *memoryLogger) Debug(msg string, args ...interface{}) {
+	e := &entry{msg: msg, fields: make(map[string]interface{})}
+
+	for index := 0; index+1 < len(args); index += 2 {
+		e.fields[args[index].(string)] = args[index+1]
+	}
+
+	logger.entries = append(logger.entries, e)
+}
+
+func TestWithLogger(t *testing.T) {
+	failures := 0
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failures > 0 {
+			failures--
+			w.WriteHeader(502)
+			return
+		}
+
+		w.Write([]byte(`{"user" : {"id" : "bob", "api_token" : "bobs-token"}}`))
+	}))
+	defer api.Close()
+
+	t.Run("when logging metadata", func(t *testing.T) {
+		logger := &memoryLogger{}
+		driver, _ := New(api.URL, "sekrit", WithLogger(logger))
+
+		driver.Get("users/current", nil)
+
+		t.Run("it logs the request", func(t *testing.T) {
+			if len(logger.entries) != 1 {
+				t.Fatalf("Expected 1 entry, got %d", len(logger.entries))
+			}
+
+			fields := logger.entries[0].fields
+
+			if fields["method"] != "GET" || fields["url"] != api.URL+"/users/current" || fields["status"] != 200 {
+				t.Errorf("Unexpected fields %v", fields)
+			}
+
+			if _, ok := fields["duration"].(time.Duration); !ok {
+				t.Errorf("Expected a duration, got %v", fields["duration"])
+			}
+		})
+
+		t.Run("it does not log bodies", func(t *testing.T) {
+			if _, ok := logger.entries[0].fields["response_body"]; ok {
+				t.Errorf("Expected no response body")
+			}
+		})
+	})
+
+	t.Run("when logging bodies", func(t *testing.T) {
+		logger := &memoryLogger{}
+		driver, _ := New(api.URL, "sekrit", WithLogger(logger), WithBodyLogging())
+
+		driver.Put("users/current", nil, []byte(`{"user" : {"password" : "hunter2"}}`))
+
+		logged := fmt.Sprint(logger.entries[0].fields)
+
+		t.Run("it logs the bodies", func(t *testing.T) {
+			if !strings.Contains(logged, "bob") {
+				t.Errorf("Expected the response body to be logged, got %s", logged)
+			}
+		})
+
+		t.Run("it redacts credentials", func(t *testing.T) {
+			for _, secret := range []string{"sekrit", "bobs-token", "hunter2"} {
+				if strings.Contains(logged, secret) {
+					t.Errorf("Expected '%s' to be redacted, got %s", secret, logged)
+				}
+			}
+		})
+	})
+
+	t.Run("when requests are retried", func(t *testing.T) {
+		failures = 1
+		logger := &memoryLogger{}
+		driver, _ := New(
+			api.URL,
+			"sekrit",
+			WithLogger(logger),
+			WithMiddleware(Retry(1, time.Millisecond)),
+		)
+
+		driver.Get("users/current", nil)
+
+		t.Run("it logs every attempt", func(t *testing.T) {
+			if len(logger.entries) != 2 {
+				t.Fatalf("Expected 2 entries, got %d", len(logger.entries))
+			}
+
+			if logger.entries[0].fields["status"] != 502 || logger.entries[1].fields["attempt"] != 2 {
+				t.Errorf("Unexpected entries %v, %v", logger.entries[0].fields, logger.entries[1].fields)
+			}
+		})
+	})
+
+	t.Run("when the request fails outright", func(t *testing.T) {
+		logger := &memoryLogger{}
+		driver, _ := New("http://127.0.0.1:1", "sekrit", WithLogger(logger))
+
+		driver.Get("users/current", nil)
+
+		t.Run("it logs the error", func(t *testing.T) {
+			if len(logger.entries) != 1 || logger.entries[0].fields["error"] == nil {
+				t.Errorf("Expected the error to be logged")
+			}
+		})
+	})
+}
+
+func TestDebugFromEnvironment(t *testing.T) {
+	t.Run("when EY_DEBUG is set", func(t *testing.T) {
+		os.Setenv("EY_DEBUG", "bodies")
+		defer os.Unsetenv("EY_DEBUG")
+
+		driver, _ := New("https://api.engineyard.com", "sekrit")
+
+		t.Run("logging is enabled", func(t *testing.T) {
+			if driver.logger == nil || !driver.logBodies {
+				t.Errorf("Expected logging with bodies to be enabled")
+			}
+		})
+	})
+
+	t.Run("when EY_DEBUG is not set", func(t *testing.T) {
+		os.Unsetenv("EY_DEBUG")
+
+		driver, _ := New("https://api.engineyard.com", "sekrit")
+
+		t.Run("logging is disabled", func(t *testing.T) {
+			if driver.logger != nil {
+				t.Errorf("Expected logging to be disabled")
+			}
+		})
+	})
+}
+
+func TestNewWriterLogger(t *testing.T) {
+	var out bytes.Buffer
+
+	NewWriterLogger(&out).Debug("maury request", "method", "GET", "error", "no such host")
+
+	t.Run("it writes key=value pairs", func(t *testing.T) {
+		if !strings.Contains(out.String(), `maury request method=GET error="no such host"`) {
+			t.Errorf("Unexpected output '%s'", out.String())
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// RoundTrip sends a single request to the upstream API and returns its
+// response
+type RoundTrip func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTrip, allowing it to observe or alter requests on
+// their way out and responses on their way back. Middleware sees requests
+// after they've been authenticated.
+type Middleware func(next RoundTrip) RoundTrip
+
+func chain(final RoundTrip, middleware []Middleware) RoundTrip {
+	roundTrip := final
+
+	for index := len(middleware) - 1; index >= 0; index-- {
+		roundTrip = middleware[index](roundTrip)
+	}
+
+	return roundTrip
+}
+
+// Header returns a Middleware that sets the given header on every request
+func Header(name string, value string) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+			request.Header.Set(name, value)
+
+			return next(request)
+		}
+	}
+}
+
+type attemptKey struct{}
+
+// Attempt returns the number of the attempt that the given request represents
+// when it is being retried by the Retry middleware, starting from 1. Requests
+// that aren't being retried are always on their first attempt.
+func Attempt(request *http.Request) int {
+	if attempt, ok := request.Context().Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+
+	return 1
+}
+
+// Retry returns a Middleware that retries idempotent requests (GET, PUT and
+// DELETE) up to the given number of additional times when they fail due to
+// network errors, server errors or rate limiting. The delay between attempts
+// doubles after each attempt.
+func Retry(retries int, delay time.Duration) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+			if !idempotent(request.Method) {
+				return next(request)
+			}
+
+			body, err := ioutil.ReadAll(request.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			request.Body.Close()
+
+			wait := delay
+
+			for attempt := 1; ; attempt++ {
+				current := request.WithContext(
+					context.WithValue(request.Context(), attemptKey{}, attempt),
+				)
+				current.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+				response, err := next(current)
+
+				if attempt > retries || !retryable(response, err) {
+					return response, err
+				}
+
+				if response != nil {
+					response.Body.Close()
+				}
+
+				select {
+				case <-request.Context().Done():
+					return nil, request.Context().Err()
+				case <-time.After(wait):
+				}
+
+				wait = wait * 2
+			}
+		}
+	}
+}
+
+func idempotent(verb string) bool {
+	switch verb {
+	case "GET", "HEAD", "PUT", "DELETE":
+		return true
+	}
+
+	return false
+}
+
+func retryable(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	api := newUpstream("sekrit", "")
+	defer api.Close()
+
+	t.Run("when several middlewares are given", func(t *testing.T) {
+		var order []string
+
+		trace := func(name string) Middleware {
+			return func(next RoundTrip) RoundTrip {
+				return func(request *http.Request) (*http.Response, error) {
+					order = append(order, name+" out")
+					response, err := next(request)
+					order = append(order, name+" in")
+
+					return response, err
+				}
+			}
+		}
+
+		driver, _ := New(api.URL, "sekrit", WithMiddleware(trace("outer"), trace("inner")))
+		driver.Get("sausages", nil)
+
+		expected := "outer out,inner out,inner in,outer in"
+
+		t.Run("they run in the order given", func(t *testing.T) {
+			if strings.Join(order, ",") != expected {
+				t.Errorf("Expected '%s', got '%s'", expected, strings.Join(order, ","))
+			}
+		})
+	})
+
+	t.Run("when a middleware mutates the response", func(t *testing.T) {
+		mutate := func(next RoundTrip) RoundTrip {
+			return func(request *http.Request) (*http.Response, error) {
+				response, err := next(request)
+				if err != nil {
+					return nil, err
+				}
+
+				response.Body.Close()
+				response.Body = ioutil.NopCloser(strings.NewReader(`{"sausages" : "silver"}`))
+
+				return response, nil
+			}
+		}
+
+		driver, _ := New(api.URL, "sekrit", WithMiddleware(mutate))
+		result, _ := driver.Get("sausages", nil)
+
+		t.Run("the caller sees the mutated response", func(t *testing.T) {
+			if string(result) != `{"sausages" : "silver"}` {
+				t.Errorf("Unexpected result '%s'", string(result))
+			}
+		})
+	})
+
+	t.Run("when a middleware sees the request", func(t *testing.T) {
+		var seen string
+
+		peek := func(next RoundTrip) RoundTrip {
+			return func(request *http.Request) (*http.Response, error) {
+				seen = request.Header.Get("X-EY-TOKEN")
+				return next(request)
+			}
+		}
+
+		driver, _ := New(api.URL, "sekrit", WithMiddleware(peek))
+		driver.Get("sausages", nil)
+
+		t.Run("the request is already authenticated", func(t *testing.T) {
+			if seen != "sekrit" {
+				t.Errorf("Expected the token to be set, got '%s'", seen)
+			}
+		})
+	})
+}
+
+func TestHeader(t *testing.T) {
+	var seen string
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header.Get("X-Request-Source")
+	}))
+	defer api.Close()
+
+	driver, _ := New(api.URL, "sekrit", WithMiddleware(Header("X-Request-Source", "billing-reports")))
+	driver.Get("sausages", nil)
+
+	t.Run("it sets the header", func(t *testing.T) {
+		if seen != "billing-reports" {
+			t.Errorf("Expected 'billing-reports', got '%s'", seen)
+		}
+	})
+}
+
+func TestRetry(t *testing.T) {
+	failures := 0
+	var attempts []int
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		if failures > 0 {
+			failures--
+			w.WriteHeader(503)
+			return
+		}
+
+		w.Write(body)
+	}))
+	defer api.Close()
+
+	record := func(next RoundTrip) RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+			attempts = append(attempts, Attempt(request))
+			return next(request)
+		}
+	}
+
+	driver, _ := New(api.URL, "sekrit", WithMiddleware(Retry(2, time.Millisecond), record))
+
+	t.Run("when the API recovers", func(t *testing.T) {
+		failures = 2
+		attempts = nil
+
+		result, err := driver.Put("sausages", nil, []byte(`{"sausages" : "gold"}`))
+
+		t.Run("it succeeds", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it resends the body", func(t *testing.T) {
+			if string(result) != `{"sausages" : "gold"}` {
+				t.Errorf("Unexpected result '%s'", string(result))
+			}
+		})
+
+		t.Run("it numbers the attempts", func(t *testing.T) {
+			if len(attempts) != 3 || attempts[0] != 1 || attempts[2] != 3 {
+				t.Errorf("Expected attempts 1 through 3, got %v", attempts)
+			}
+		})
+	})
+
+	t.Run("when the API does not recover", func(t *testing.T) {
+		failures = 5
+		attempts = nil
+
+		_, err := driver.Get("sausages", nil)
+
+		t.Run("it gives up", func(t *testing.T) {
+			if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != 503 {
+				t.Errorf("Expected a 503 APIError, got %v", err)
+			}
+
+			if len(attempts) != 3 {
+				t.Errorf("Expected 3 attempts, got %d", len(attempts))
+			}
+		})
+	})
+
+	t.Run("when the request is not idempotent", func(t *testing.T) {
+		failures = 1
+		attempts = nil
+
+		_, err := driver.Post("sausages", nil, []byte(`{}`))
+
+		t.Run("it is not retried", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+
+			if len(attempts) != 1 {
+				t.Errorf("Expected 1 attempt, got %d", len(attempts))
+			}
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option is a function that configures a Driver
+type Option func(*Driver)
+
+// WithTransport configures a Driver to send its requests via the given
+// transport rather than http.DefaultTransport
+func WithTransport(transport http.RoundTripper) Option {
+	return func(driver *Driver) {
+		driver.raw.Transport = transport
+	}
+}
+
+// WithAuthenticator configures a Driver to authenticate its requests with the
+// given Authenticator rather than a static token
+func WithAuthenticator(auth Authenticator) Option {
+	return func(driver *Driver) {
+		driver.auth = auth
+	}
+}
+
+// WithLogger configures a Driver to log every request that it makes, including
+// each retry attempt, to the given Logger. Credentials are redacted.
+func WithLogger(logger Logger) Option {
+	return func(driver *Driver) {
+		driver.logger = logger
+	}
+}
+
+// WithBodyLogging configures a Driver with a logger to include request and
+// response bodies in its logs. Credentials are redacted.
+func WithBodyLogging() Option {
+	return func(driver *Driver) {
+		driver.logBodies = true
+	}
+}
+
+// WithMiddleware configures a Driver to pass its requests through the given
+// middleware. The first middleware given is the outermost, so it sees each
+// request first and each response last. Calling it more than once appends to
+// the chain.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(driver *Driver) {
+		driver.middleware = append(driver.middleware, middleware...)
+	}
+}
+
+// WithCache configures a Driver to cache the responses to its GET requests in
+// the given Cache. The same Cache can be shared by several Drivers.
+func WithCache(cache *Cache) Option {
+	return func(driver *Driver) {
+		driver.cache = cache
+	}
+}
+
+// WithTimeout configures the amount of time that a Driver waits for a
+// response before giving up on a request
+func WithTimeout(timeout time.Duration) Option {
+	return func(driver *Driver) {
+		driver.raw.Timeout = timeout
+	}
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type transport struct {
+	requests []*http.Request
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, request)
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"sausages" : "gold"}`)),
+		Header:     http.Header{},
+		Request:    request,
+	}, nil
+}
+
+func TestWithTransport(t *testing.T) {
+	custom := &transport{}
+	driver, _ := New("https://api.engineyard.com", "faketoken", WithTransport(custom))
+
+	result, err := driver.Get("sausages", nil)
+
+	t.Run("it is a success", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it uses the given transport", func(t *testing.T) {
+		if len(custom.requests) != 1 {
+			t.Fatalf("Expected 1 request via the transport, got %d", len(custom.requests))
+		}
+
+		if custom.requests[0].URL.String() != "https://api.engineyard.com/sausages" {
+			t.Errorf("Unexpected URL %s", custom.requests[0].URL)
+		}
+	})
+
+	t.Run("it returns the transport's response", func(t *testing.T) {
+		if string(result) != `{"sausages" : "gold"}` {
+			t.Errorf("Unexpected result '%s'", string(result))
+		}
+	})
+}
+
+func TestWithTimeout(t *testing.T) {
+	driver, _ := New("https://api.engineyard.com", "faketoken", WithTimeout(time.Second))
+
+	t.Run("it sets the timeout", func(t *testing.T) {
+		if driver.raw.Timeout != time.Second {
+			t.Errorf("Expected a 1s timeout, got %s", driver.raw.Timeout)
+		}
+	})
+}
+
+func TestDriver_WithContext(t *testing.T) {
+	custom := &transport{}
+	driver, _ := New("https://api.engineyard.com", "faketoken", WithTransport(custom))
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "sausages")
+
+	bound := driver.WithContext(ctx)
+	bound.Get("sausages", nil)
+
+	t.Run("requests carry the context", func(t *testing.T) {
+		if len(custom.requests) != 1 || custom.requests[0].Context().Value(key{}) != "sausages" {
+			t.Errorf("Expected the request to carry the context")
+		}
+	})
+
+	t.Run("the original driver is unchanged", func(t *testing.T) {
+		if driver.ctx == ctx {
+			t.Errorf("Expected the original driver to keep its context")
+		}
+	})
+
+	t.Run("when the context is cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		api := newUpstream("faketoken", "")
+		defer api.Close()
+
+		live, _ := New(api.URL, "faketoken")
+		_, err := live.WithContext(cancelled).Get("sausages", nil)
+
+		t.Run("the request fails", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
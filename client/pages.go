@@ -0,0 +1,62 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// PerPage is the number of results requested for each page of a collection
+const PerPage = 100
+
+// Getter is the subset of the Driver used to walk paginated collections
+type Getter interface {
+	Get(string, url.Values) ([]byte, error)
+}
+
+// EachPage requests the pages of the collection at the given path in order,
+// passing each response to decode, which reports how many results the page
+// held. It stops after the first page with fewer than PerPage results, or at
+// the first error from either the API or decode, in which case that error is
+// returned. The given params are passed along to the API, but are not
+// modified.
+func EachPage(driver Getter, path string, params url.Values, decode func([]byte) (int, error)) error {
+	query := url.Values{}
+
+	for key, values := range params {
+		query[key] = append([]string(nil), values...)
+	}
+
+	query.Set("per_page", strconv.Itoa(PerPage))
+
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		response, err := driver.Get(path, query)
+		if err != nil {
+			return err
+		}
+
+		count, err := decode(response)
+		if err != nil {
+			return err
+		}
+
+		if count < PerPage {
+			return nil
+		}
+	}
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
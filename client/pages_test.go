@@ -0,0 +1,132 @@
+package client
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+type pagedGetter struct {
+	sizes  []int
+	fail   map[int]error
+	params []url.Values
+}
+
+func (getter *pagedGetter) Get(path string, params url.Values) ([]byte, error) {
+	copied := url.Values{}
+	for key, values := range params {
+		copied[key] = append([]string(nil), values...)
+	}
+
+	getter.params = append(getter.params, copied)
+
+	page, _ := strconv.Atoi(params.Get("page"))
+
+	if err := getter.fail[page]; err != nil {
+		return nil, err
+	}
+
+	if page > len(getter.sizes) {
+		return []byte("0"), nil
+	}
+
+	return []byte(strconv.Itoa(getter.sizes[page-1])), nil
+}
+
+func count(response []byte) (int, error) {
+	return strconv.Atoi(string(response))
+}
+
+func TestEachPage(t *testing.T) {
+	t.Run("when the collection spans several pages", func(t *testing.T) {
+		getter := &pagedGetter{sizes: []int{PerPage, PerPage, 3}}
+		params := url.Values{}
+		params.Set("name", "sausages")
+
+		total := 0
+		err := EachPage(getter, "sausages", params, func(response []byte) (int, error) {
+			found, err := count(response)
+			total += found
+			return found, err
+		})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it stops after the short page", func(t *testing.T) {
+			if len(getter.params) != 3 {
+				t.Errorf("Expected 3 requests, got %d", len(getter.params))
+			}
+
+			if total != 2*PerPage+3 {
+				t.Errorf("Expected %d results, got %d", 2*PerPage+3, total)
+			}
+		})
+
+		t.Run("it requests the pages in order", func(t *testing.T) {
+			for index, sent := range getter.params {
+				if sent.Get("page") != strconv.Itoa(index+1) {
+					t.Errorf("Expected page %d, got %s", index+1, sent.Get("page"))
+				}
+
+				if sent.Get("per_page") != strconv.Itoa(PerPage) {
+					t.Errorf("Expected per_page %d, got %s", PerPage, sent.Get("per_page"))
+				}
+
+				if sent.Get("name") != "sausages" {
+					t.Errorf("Expected the given params to be passed along")
+				}
+			}
+		})
+
+		t.Run("it leaves the given params alone", func(t *testing.T) {
+			if len(params) != 1 {
+				t.Errorf("Expected the params to be unmodified, got %v", params)
+			}
+		})
+	})
+
+	t.Run("when the API fails partway through", func(t *testing.T) {
+		failure := errors.New("Bad Gateway")
+		getter := &pagedGetter{sizes: []int{PerPage, PerPage}, fail: map[int]error{2: failure}}
+
+		err := EachPage(getter, "sausages", nil, count)
+
+		t.Run("it returns the error", func(t *testing.T) {
+			if err != failure {
+				t.Errorf("Expected the API error, got %v", err)
+			}
+		})
+
+		t.Run("it stops requesting pages", func(t *testing.T) {
+			if len(getter.params) != 2 {
+				t.Errorf("Expected 2 requests, got %d", len(getter.params))
+			}
+		})
+	})
+
+	t.Run("when a page can't be decoded", func(t *testing.T) {
+		failure := errors.New("invalid character")
+		getter := &pagedGetter{sizes: []int{PerPage, PerPage}}
+
+		err := EachPage(getter, "sausages", nil, func(response []byte) (int, error) {
+			return 0, failure
+		})
+
+		t.Run("it returns the error", func(t *testing.T) {
+			if err != failure {
+				t.Errorf("Expected the decode error, got %v", err)
+			}
+		})
+
+		t.Run("it stops requesting pages", func(t *testing.T) {
+			if len(getter.params) != 1 {
+				t.Errorf("Expected 1 request, got %d", len(getter.params))
+			}
+		})
+	})
+}
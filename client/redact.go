@@ -0,0 +1,119 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// Redacted is the value that replaces secrets when requests and responses are
+// logged or recorded
+const Redacted = "REDACTED"
+
+var secretHeaders = []string{"X-EY-TOKEN", "Authorization"}
+
+var secretFields = map[string]bool{
+	"api_token":     true,
+	"access_token":  true,
+	"refresh_token": true,
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+	"credentials":   true,
+}
+
+// RedactHeader returns a copy of the given header with the values of headers
+// that carry credentials replaced
+func RedactHeader(header http.Header) http.Header {
+	redacted := http.Header{}
+
+	for name, values := range header {
+		redacted[name] = values
+	}
+
+	for _, name := range secretHeaders {
+		if len(redacted.Get(name)) > 0 {
+			redacted.Set(name, Redacted)
+		}
+	}
+
+	return redacted
+}
+
+// RedactBody returns a copy of the given JSON body with the values of fields
+// that carry credentials, such as api_token, replaced. Bodies that are not
+// JSON, or that carry no credentials, are returned as-is.
+func RedactBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	var document interface{}
+
+	// Numbers are kept as json.Number so that large IDs survive re-encoding
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&document); err != nil {
+		return body
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return body
+	}
+
+	if !redactValue(document) {
+		return body
+	}
+
+	redacted, err := json.Marshal(document)
+	if err != nil {
+		return body
+	}
+
+	return redacted
+}
+
+// redactValue replaces secrets in the given document in place and reports
+// whether any were found
+func redactValue(value interface{}) bool {
+	found := false
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			if secretFields[key] {
+				typed[key] = Redacted
+				found = true
+				continue
+			}
+
+			if redactValue(nested) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for _, nested := range typed {
+			if redactValue(nested) {
+				found = true
+			}
+		}
+	}
+
+	return found
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
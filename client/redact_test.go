@@ -0,0 +1,46 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	t.Run("when the body carries a secret", func(t *testing.T) {
+		redacted := string(RedactBody([]byte(`{"user" : {"id" : 9007199254740993, "api_token" : "sekrit"}}`)))
+
+		t.Run("it replaces the secret", func(t *testing.T) {
+			if strings.Contains(redacted, "sekrit") || !strings.Contains(redacted, Redacted) {
+				t.Errorf("Expected the token to be redacted, got %s", redacted)
+			}
+		})
+
+		t.Run("it preserves large numbers", func(t *testing.T) {
+			if !strings.Contains(redacted, "9007199254740993") {
+				t.Errorf("Expected the ID to survive, got %s", redacted)
+			}
+		})
+	})
+
+	t.Run("when the body carries no secrets", func(t *testing.T) {
+		body := `{"zebra" : 1, "id" : 9007199254740993, "apple" : [1.50, 2]}`
+		redacted := string(RedactBody([]byte(body)))
+
+		t.Run("it returns the body untouched", func(t *testing.T) {
+			if redacted != body {
+				t.Errorf("Expected %s, got %s", body, redacted)
+			}
+		})
+	})
+
+	t.Run("when the body is not JSON", func(t *testing.T) {
+		body := `api_token=sekrit`
+		redacted := string(RedactBody([]byte(body)))
+
+		t.Run("it returns the body untouched", func(t *testing.T) {
+			if redacted != body {
+				t.Errorf("Expected %s, got %s", body, redacted)
+			}
+		})
+	})
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/client"
+)
+
+var accountHeaders = []string{"ID", "NAME", "PLAN", "SUPPORT PLAN", "CANCELLED"}
+
+func accountRow(account *accounts.Entity) []string {
+	return []string{
+		account.ID,
+		account.Name,
+		string(account.Plan),
+		string(account.SupportPlan),
+		strconv.FormatBool(account.Cancelled()),
+	}
+}
+
+func listAccounts(driver *client.Driver, args []string, out *output) error {
+	all, err := accounts.All(driver, nil)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+
+	for _, account := range all {
+		rows = append(rows, accountRow(account))
+	}
+
+	return out.list(all, accountHeaders, rows)
+}
+
+func showAccount(driver *client.Driver, args []string, out *output) error {
+	if len(args) != 1 {
+		return errors.New("usage: maury accounts show <id>")
+	}
+
+	account, err := accounts.Find(driver, args[0])
+	if err != nil {
+		return err
+	}
+
+	if account == nil {
+		return errors.New("account " + args[0] + " not found")
+	}
+
+	return out.show(account, accountFields(), accountValues(account))
+}
+
+func updateAccount(driver *client.Driver, args []string, out *output) error {
+	if len(args) == 0 || len(args[0]) == 0 || strings.HasPrefix(args[0], "-") {
+		return errors.New("usage: maury accounts update <id> [--name NAME] [--emergency-contact CONTACT] [--support-plan PLAN]")
+	}
+
+	id := args[0]
+
+	flags := flag.NewFlagSet("accounts update", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+
+	name := flags.String("name", "", "the new name for the account")
+	contact := flags.String("emergency-contact", "", "the new emergency contact for the account")
+	plan := flags.String("support-plan", "", "the new support plan for the account")
+
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	changes := &accounts.Changes{
+		Name:             *name,
+		EmergencyContact: *contact,
+		SupportPlan:      accounts.SupportPlan(*plan),
+	}
+
+	if *changes == (accounts.Changes{}) {
+		return errors.New("no changes given")
+	}
+
+	account, err := accounts.Update(driver, &accounts.Entity{ID: id}, changes)
+	if err != nil {
+		return err
+	}
+
+	if account == nil {
+		return errors.New("account " + id + " not found")
+	}
+
+	return out.show(account, accountFields(), accountValues(account))
+}
+
+func accountFields() []string {
+	return []string{
+		"ID",
+		"Name",
+		"Type",
+		"Plan",
+		"Support Plan",
+		"Emergency Contact",
+		"Billable",
+		"Created",
+		"Cancelled",
+	}
+}
+
+func accountValues(account *accounts.Entity) []string {
+	return []string{
+		account.ID,
+		account.Name,
+		string(account.Type),
+		string(account.Plan),
+		string(account.SupportPlan),
+		account.EmergencyContact,
+		strconv.FormatBool(account.Billable),
+		account.CreatedAt.String(),
+		account.CancelledAt.String(),
+	}
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,143 @@
+// Command maury is a command-line tool for querying the Engine Yard API
+//
+// Usage:
+//
+//	maury [global options] <command> [arguments]
+//
+// The commands are:
+//
+//	accounts list                     list all visible accounts
+//	accounts show <id>                show a single account
+//	accounts update <id> [options]    update an account
+//	users list                        list all visible users
+//	users show <id or email>          show a single user
+//	whoami                            show the user that owns the token
+//
+// The API URL and token are resolved from the named profile, the
+// environment and the config files in the home directory, as described in the
+// config package. The --api-url and --token options override them.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/config"
+)
+
+// command is the signature shared by all of the subcommands
+type command func(driver *client.Driver, args []string, out *output) error
+
+var commands = map[string]map[string]command{
+	"accounts": {
+		"list":   listAccounts,
+		"show":   showAccount,
+		"update": updateAccount,
+	},
+	"users": {
+		"list": listUsers,
+		"show": showUser,
+	},
+}
+
+func main() {
+	err := run(os.Args[1:], os.Getenv, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "maury: "+err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(args []string, getenv func(string) string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("maury", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+
+	profile := flags.String("profile", "", "the name of the configured profile to use")
+	token := flags.String("token", "", "the Engine Yard API token to use")
+	apiURL := flags.String("api-url", "", "the base URL of the Engine Yard API")
+	format := flags.String("format", "table", "the output format (table, json or yaml)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	out, err := newOutput(*format, stdout)
+	if err != nil {
+		return err
+	}
+
+	remaining := flags.Args()
+	if len(remaining) == 0 {
+		return errors.New("no command given")
+	}
+
+	var cmd command
+
+	if remaining[0] == "whoami" {
+		cmd = whoami
+		remaining = remaining[1:]
+	} else {
+		actions, ok := commands[remaining[0]]
+		if !ok {
+			return fmt.Errorf("unknown command '%s'", remaining[0])
+		}
+
+		if len(remaining) < 2 {
+			return fmt.Errorf("no action given for '%s'", remaining[0])
+		}
+
+		cmd, ok = actions[remaining[1]]
+		if !ok {
+			return fmt.Errorf("unknown action '%s %s'", remaining[0], remaining[1])
+		}
+
+		remaining = remaining[2:]
+	}
+
+	driver, err := newDriver(*profile, *apiURL, *token, getenv)
+	if err != nil {
+		return err
+	}
+
+	return cmd(driver, remaining, out)
+}
+
+// newDriver resolves the configured profile, treating the --api-url and
+// --token options as though they were set in the environment
+func newDriver(name string, apiURL string, token string, getenv func(string) string) (*client.Driver, error) {
+	overrides := map[string]string{"EY_API_URL": apiURL, "EY_TOKEN": token}
+
+	lookup := func(variable string) string {
+		if value := overrides[variable]; len(value) > 0 {
+			return value
+		}
+
+		return getenv(variable)
+	}
+
+	profile, err := config.Resolve(name, lookup, config.DefaultPaths(getenv("HOME"))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile.Driver()
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
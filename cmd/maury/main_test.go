@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/maurytest"
+	"github.com/ess/maury/users"
+)
+
+func TestRun(t *testing.T) {
+	server := maurytest.NewServer("sekrit")
+	defer server.Close()
+
+	server.AddAccount(&accounts.Entity{ID: "1", Name: "Sausage Factory", Plan: accounts.PlanStandard})
+	server.AddAccount(&accounts.Entity{ID: "2", Name: "Bratwurst Bakery"})
+
+	bob := &users.Entity{ID: "bob", Name: "Bob", Email: "bob@example.com", APIToken: "sekrit"}
+	server.AddUser(bob)
+	server.SetCurrentUser(bob)
+
+	env := map[string]string{"EY_API_URL": server.URL, "EY_TOKEN": "sekrit"}
+	getenv := func(name string) string { return env[name] }
+
+	maury := func(args ...string) (string, error) {
+		var out bytes.Buffer
+
+		err := run(args, getenv, &out)
+
+		return out.String(), err
+	}
+
+	t.Run("when listing accounts", func(t *testing.T) {
+		out, err := maury("accounts", "list")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it prints a table", func(t *testing.T) {
+			if !strings.HasPrefix(out, "ID") || !strings.Contains(out, "Sausage Factory") || !strings.Contains(out, "Bratwurst Bakery") {
+				t.Errorf("Unexpected output:\n%s", out)
+			}
+		})
+	})
+
+	t.Run("when showing an account as JSON", func(t *testing.T) {
+		out, err := maury("--format", "json", "accounts", "show", "1")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it prints the entity as JSON", func(t *testing.T) {
+			if !strings.Contains(out, `"name": "Sausage Factory"`) {
+				t.Errorf("Unexpected output:\n%s", out)
+			}
+		})
+	})
+
+	t.Run("when showing an account as YAML", func(t *testing.T) {
+		out, _ := maury("--format", "yaml", "accounts", "show", "1")
+
+		t.Run("it prints the entity as YAML", func(t *testing.T) {
+			if !strings.Contains(out, "name: Sausage Factory") {
+				t.Errorf("Unexpected output:\n%s", out)
+			}
+		})
+	})
+
+	t.Run("when updating an account", func(t *testing.T) {
+		out, err := maury("accounts", "update", "2", "--name", "Kielbasa Kitchen")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it prints the updated account", func(t *testing.T) {
+			if !strings.Contains(out, "Kielbasa Kitchen") {
+				t.Errorf("Unexpected output:\n%s", out)
+			}
+		})
+
+		t.Run("the account is updated upstream", func(t *testing.T) {
+			if server.Account("2").Name != "Kielbasa Kitchen" {
+				t.Errorf("Expected the account to be renamed")
+			}
+		})
+	})
+
+	t.Run("when updating an account without changes", func(t *testing.T) {
+		_, err := maury("accounts", "update", "2")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when updating an account without an ID", func(t *testing.T) {
+		_, err := maury("accounts", "update", "", "--name", "Sausages")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the API returns no account", func(t *testing.T) {
+		server.Fail("GET", "accounts/1", 200, `{}`)
+		defer server.ClearFailures()
+
+		_, err := maury("accounts", "show", "1")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when showing a user by email", func(t *testing.T) {
+		out, err := maury("users", "show", "bob@example.com")
+
+		t.Run("it finds the user", func(t *testing.T) {
+			if err != nil || !strings.Contains(out, "bob@example.com") {
+				t.Errorf("Unexpected output (%v):\n%s", err, out)
+			}
+		})
+	})
+
+	t.Run("when asking who am I", func(t *testing.T) {
+		out, err := maury("--format", "json", "whoami")
+
+		t.Run("it shows the current user", func(t *testing.T) {
+			if err != nil || !strings.Contains(out, `"id": "bob"`) {
+				t.Errorf("Unexpected output (%v):\n%s", err, out)
+			}
+		})
+
+		t.Run("it does not show the token", func(t *testing.T) {
+			if strings.Contains(out, "sekrit") {
+				t.Errorf("Expected the API token to be hidden")
+			}
+		})
+	})
+
+	t.Run("when the API returns no current user", func(t *testing.T) {
+		server.Fail("GET", "users/current", 200, `{}`)
+		defer server.ClearFailures()
+
+		_, err := maury("whoami")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when listing with a bad token", func(t *testing.T) {
+		env["EY_TOKEN"] = "wrong"
+		defer func() { env["EY_TOKEN"] = "sekrit" }()
+
+		_, accountsErr := maury("accounts", "list")
+		_, usersErr := maury("users", "list")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if accountsErr == nil || usersErr == nil {
+				t.Errorf("Expected errors, got %v and %v", accountsErr, usersErr)
+			}
+		})
+	})
+
+	t.Run("when the command is unknown", func(t *testing.T) {
+		_, err := maury("sausages", "list")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the format is unknown", func(t *testing.T) {
+		_, err := maury("--format", "xml", "accounts", "list")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the token comes from ~/.eyrc", func(t *testing.T) {
+		home, _ := ioutil.TempDir("", "maury")
+		defer os.RemoveAll(home)
+
+		ioutil.WriteFile(
+			filepath.Join(home, ".eyrc"),
+			[]byte("---\n"+server.URL+"/: sekrit\n"),
+			0600,
+		)
+
+		getenv := func(name string) string {
+			return map[string]string{"EY_API_URL": server.URL, "HOME": home}[name]
+		}
+
+		var out bytes.Buffer
+		err := run([]string{"whoami"}, getenv, &out)
+
+		t.Run("it uses the token", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	})
+
+	t.Run("when there is no token", func(t *testing.T) {
+		var out bytes.Buffer
+		err := run([]string{"whoami"}, func(string) string { return "" }, &out)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// output knows how to render API entities in the format that the user asked
+// for. Tables are built from the given headers and rows, while JSON and YAML
+// are rendered from the entities themselves.
+type output struct {
+	format string
+	writer io.Writer
+}
+
+func newOutput(format string, writer io.Writer) (*output, error) {
+	switch format {
+	case "table", "json", "yaml":
+		return &output{format: format, writer: writer}, nil
+	}
+
+	return nil, fmt.Errorf("unknown output format '%s'", format)
+}
+
+// list renders a collection of entities
+func (out *output) list(entities interface{}, headers []string, rows [][]string) error {
+	if out.format == "table" {
+		return out.table(headers, rows)
+	}
+
+	return out.document(entities)
+}
+
+// show renders a single entity as a two-column table of field names and
+// values
+func (out *output) show(entity interface{}, fields []string, values []string) error {
+	if out.format != "table" {
+		return out.document(entity)
+	}
+
+	var rows [][]string
+
+	for index, field := range fields {
+		rows = append(rows, []string{field + ":", values[index]})
+	}
+
+	return out.table(nil, rows)
+}
+
+func (out *output) table(headers []string, rows [][]string) error {
+	writer := tabwriter.NewWriter(out.writer, 0, 8, 2, ' ', 0)
+
+	if len(headers) > 0 {
+		fmt.Fprintln(writer, strings.Join(headers, "\t"))
+	}
+
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	return writer.Flush()
+}
+
+func (out *output) document(entity interface{}) error {
+	data, err := json.MarshalIndent(entity, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if out.format == "json" {
+		_, err = fmt.Fprintln(out.writer, string(data))
+		return err
+	}
+
+	// JSON is valid YAML, so round-tripping through it gives us YAML that uses
+	// the same field names as the API.
+	var generic interface{}
+
+	err = yaml.Unmarshal(data, &generic)
+	if err != nil {
+		return err
+	}
+
+	data, err = yaml.Marshal(generic)
+	if err != nil {
+		return err
+	}
+
+	_, err = out.writer.Write(data)
+	return err
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/users"
+)
+
+var userHeaders = []string{"ID", "NAME", "EMAIL", "ROLE", "STAFF"}
+
+func userRow(user *users.Entity) []string {
+	return []string{
+		user.ID,
+		user.Name,
+		user.Email,
+		user.Role,
+		strconv.FormatBool(user.Staff),
+	}
+}
+
+func listUsers(driver *client.Driver, args []string, out *output) error {
+	all, err := users.All(driver, nil)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+
+	for _, user := range all {
+		redact(user)
+		rows = append(rows, userRow(user))
+	}
+
+	return out.list(all, userHeaders, rows)
+}
+
+func showUser(driver *client.Driver, args []string, out *output) error {
+	if len(args) != 1 {
+		return errors.New("usage: maury users show <id or email>")
+	}
+
+	var user *users.Entity
+	var err error
+
+	if strings.Contains(args[0], "@") {
+		user, err = users.FindByEmail(driver, args[0])
+	} else {
+		user, err = users.Find(driver, args[0])
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return errors.New("user " + args[0] + " not found")
+	}
+
+	redact(user)
+
+	return out.show(user, userFields(), userValues(user))
+}
+
+func whoami(driver *client.Driver, args []string, out *output) error {
+	user, err := users.Current(driver)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return errors.New("the API did not return the current user")
+	}
+
+	redact(user)
+
+	return out.show(user, userFields(), userValues(user))
+}
+
+// redact removes the user's API token so that it never ends up on the screen
+// or in a log
+func redact(user *users.Entity) {
+	user.APIToken = ""
+}
+
+func userFields() []string {
+	return []string{"ID", "Name", "Email", "Role", "Staff", "Verified", "Created"}
+}
+
+func userValues(user *users.Entity) []string {
+	return []string{
+		user.ID,
+		user.Name,
+		user.Email,
+		user.Role,
+		strconv.FormatBool(user.Staff),
+		strconv.FormatBool(user.Verified),
+		user.CreatedAt.String(),
+	}
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
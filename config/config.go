@@ -0,0 +1,151 @@
+// Package config resolves the credentials and endpoint used to talk to the
+// Engine Yard API from config files and the environment, so that every tool
+// built on maury finds them the same way
+package config
+
+import (
+	"errors"
+	"os"
+
+	"github.com/ess/maury/client"
+)
+
+// DefaultAPIURL is the API URL used when no other URL is configured
+const DefaultAPIURL = "https://api.engineyard.com"
+
+// DefaultProfile is the name of the profile used when no other profile is
+// requested
+const DefaultProfile = "default"
+
+// ErrNoToken is returned when a driver is requested for a profile that has no
+// API token
+var ErrNoToken = errors.New("No Engine Yard API token is configured")
+
+// Profile is a named set of credentials for an Engine Yard API
+type Profile struct {
+	Name   string `yaml:"-" toml:"-"`
+	APIURL string `yaml:"api_url" toml:"api_url"`
+	Token  string `yaml:"token" toml:"token"`
+}
+
+// Driver returns a client.Driver configured with the profile's API URL and
+// token. Any options given are passed along to the driver. If the profile has
+// no token, ErrNoToken is returned.
+func (profile *Profile) Driver(options ...client.Option) (*client.Driver, error) {
+	if len(profile.Token) == 0 {
+		return nil, ErrNoToken
+	}
+
+	return client.New(profile.APIURL, profile.Token, options...)
+}
+
+// UnknownProfileError is returned when a profile is explicitly requested, but
+// none of the config files define it
+type UnknownProfileError struct {
+	Name string
+}
+
+func (err *UnknownProfileError) Error() string {
+	return "No profile named '" + err.Name + "' is configured"
+}
+
+// Load resolves the active profile from the config files in the user's home
+// directory and the environment
+func Load() (*Profile, error) {
+	return Resolve("", os.Getenv, DefaultPaths(os.Getenv("HOME"))...)
+}
+
+// NewDriver resolves the active profile as Load does, and returns a
+// client.Driver configured for it. Any options given are passed along to the
+// driver.
+func NewDriver(options ...client.Option) (*client.Driver, error) {
+	profile, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return profile.Driver(options...)
+}
+
+// Resolve determines the profile to use from the given config files and
+// environment. The profile is chosen by the given name, or EY_PROFILE if the
+// name is empty, or the default named in the config files, or "default".
+// EY_API_URL and EY_TOKEN override the values in the profile. If the profile
+// still has no token, the token configured for its API URL in any of the files
+// is used, which is how ~/.eyrc files are understood.
+//
+// Files that don't exist are skipped, and if several files define the same
+// profile, the first one wins. If a profile was requested by name but isn't
+// defined, the error is an *UnknownProfileError.
+func Resolve(name string, getenv func(string) string, paths ...string) (*Profile, error) {
+	merged := &File{Profiles: make(map[string]*Profile)}
+
+	for _, path := range paths {
+		file, err := ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		merged.merge(file)
+	}
+
+	explicit := true
+
+	if len(name) == 0 {
+		name = getenv("EY_PROFILE")
+	}
+
+	if len(name) == 0 {
+		explicit = false
+		name = merged.Default
+	}
+
+	if len(name) == 0 {
+		name = DefaultProfile
+	}
+
+	profile := &Profile{Name: name}
+
+	if found, ok := merged.Profiles[name]; ok {
+		*profile = *found
+		profile.Name = name
+	} else if explicit {
+		return nil, &UnknownProfileError{Name: name}
+	}
+
+	if url := getenv("EY_API_URL"); len(url) > 0 {
+		profile.APIURL = url
+	}
+
+	if len(profile.APIURL) == 0 {
+		profile.APIURL = DefaultAPIURL
+	}
+
+	if token := getenv("EY_TOKEN"); len(token) > 0 {
+		profile.Token = token
+	}
+
+	if len(profile.Token) == 0 {
+		profile.Token = merged.tokenFor(profile.APIURL)
+	}
+
+	return profile, nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
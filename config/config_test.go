@@ -0,0 +1,151 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "config")
+	defer os.RemoveAll(dir)
+
+	yamlPath := filepath.Join(dir, ".maury.yml")
+	ioutil.WriteFile(yamlPath, []byte(`
+default: production
+profiles:
+  production:
+    token: prod-token
+  staging:
+    api_url: https://api.staging.example.com
+    token: staging-token
+`), 0600)
+
+	eyrcPath := filepath.Join(dir, ".eyrc")
+	ioutil.WriteFile(eyrcPath, []byte(`---
+https://api.engineyard.com/: eyrc-token
+https://api.other.example.com/: other-token
+`), 0600)
+
+	missingPath := filepath.Join(dir, "missing.yml")
+
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+
+	t.Run("when nothing is requested", func(t *testing.T) {
+		profile, err := Resolve("", env(nil), missingPath, yamlPath, eyrcPath)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it uses the default profile from the files", func(t *testing.T) {
+			if profile.Name != "production" || profile.Token != "prod-token" {
+				t.Errorf("Expected the production profile, got %v", profile)
+			}
+		})
+
+		t.Run("it uses the default API URL", func(t *testing.T) {
+			if profile.APIURL != DefaultAPIURL {
+				t.Errorf("Expected %s, got %s", DefaultAPIURL, profile.APIURL)
+			}
+		})
+	})
+
+	t.Run("when a profile is requested by name", func(t *testing.T) {
+		profile, _ := Resolve("staging", env(nil), yamlPath)
+
+		t.Run("it uses the requested profile", func(t *testing.T) {
+			if profile.APIURL != "https://api.staging.example.com" || profile.Token != "staging-token" {
+				t.Errorf("Expected the staging profile, got %v", profile)
+			}
+		})
+	})
+
+	t.Run("when a profile is requested via EY_PROFILE", func(t *testing.T) {
+		profile, _ := Resolve("", env(map[string]string{"EY_PROFILE": "staging"}), yamlPath)
+
+		t.Run("it uses the requested profile", func(t *testing.T) {
+			if profile.Name != "staging" {
+				t.Errorf("Expected the staging profile, got %v", profile)
+			}
+		})
+	})
+
+	t.Run("when an unknown profile is requested", func(t *testing.T) {
+		_, err := Resolve("qa", env(nil), yamlPath)
+
+		t.Run("the error is an UnknownProfileError", func(t *testing.T) {
+			if _, ok := err.(*UnknownProfileError); !ok {
+				t.Errorf("Expected an *UnknownProfileError, got %T", err)
+			}
+		})
+	})
+
+	t.Run("when the environment has overrides", func(t *testing.T) {
+		profile, _ := Resolve(
+			"staging",
+			env(map[string]string{"EY_API_URL": "https://api.example.com", "EY_TOKEN": "env-token"}),
+			yamlPath,
+		)
+
+		t.Run("they win", func(t *testing.T) {
+			if profile.APIURL != "https://api.example.com" || profile.Token != "env-token" {
+				t.Errorf("Expected the environment values, got %v", profile)
+			}
+		})
+	})
+
+	t.Run("when only an ~/.eyrc is available", func(t *testing.T) {
+		t.Run("it uses the token for the default API URL", func(t *testing.T) {
+			profile, _ := Resolve("", env(nil), eyrcPath)
+
+			if profile.Token != "eyrc-token" {
+				t.Errorf("Expected eyrc-token, got '%s'", profile.Token)
+			}
+		})
+
+		t.Run("it uses the token for the requested API URL", func(t *testing.T) {
+			profile, _ := Resolve("", env(map[string]string{"EY_API_URL": "https://api.other.example.com"}), eyrcPath)
+
+			if profile.Token != "other-token" {
+				t.Errorf("Expected other-token, got '%s'", profile.Token)
+			}
+		})
+	})
+
+	t.Run("when no token can be found", func(t *testing.T) {
+		profile, err := Resolve("", env(nil), missingPath)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		_, err = profile.Driver()
+
+		t.Run("the driver can't be created", func(t *testing.T) {
+			if err != ErrNoToken {
+				t.Errorf("Expected ErrNoToken, got %v", err)
+			}
+		})
+	})
+
+	t.Run("when a file is malformed", func(t *testing.T) {
+		broken := filepath.Join(dir, "broken.yml")
+		ioutil.WriteFile(broken, []byte("profiles: [this is not a map"), 0600)
+
+		_, err := Resolve("", env(nil), broken)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
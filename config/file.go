@@ -0,0 +1,139 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// File is the contents of a profile config file
+type File struct {
+	Default  string              `yaml:"default" toml:"default"`
+	Profiles map[string]*Profile `yaml:"profiles" toml:"profiles"`
+}
+
+// DefaultPaths returns the config files that are consulted, in order, for the
+// given home directory
+func DefaultPaths(home string) []string {
+	if len(home) == 0 {
+		return nil
+	}
+
+	return []string{
+		filepath.Join(home, ".maury.yml"),
+		filepath.Join(home, ".maury.yaml"),
+		filepath.Join(home, ".maury.toml"),
+		filepath.Join(home, ".eyrc"),
+	}
+}
+
+// ReadFile reads the profile config file at the given path. Files with a
+// .toml extension are read as TOML, files named .eyrc are read as a map of API
+// URLs to tokens, and all other files are read as YAML.
+func ReadFile(path string) (*File, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{}
+
+	switch {
+	case filepath.Ext(path) == ".toml":
+		err = toml.Unmarshal(data, file)
+	case filepath.Base(path) == ".eyrc":
+		file, err = parseEyrc(data)
+	default:
+		err = yaml.Unmarshal(data, file)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for name, profile := range file.Profiles {
+		if profile == nil {
+			delete(file.Profiles, name)
+			continue
+		}
+
+		profile.Name = name
+	}
+
+	return file, nil
+}
+
+// parseEyrc converts an ~/.eyrc file, as written by the other Engine Yard
+// tools, into profiles named for the API URLs that they belong to
+func parseEyrc(data []byte) (*File, error) {
+	tokens := make(map[string]string)
+
+	err := yaml.Unmarshal(data, &tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{Profiles: make(map[string]*Profile)}
+
+	for url, token := range tokens {
+		file.Profiles[url] = &Profile{APIURL: url, Token: token}
+	}
+
+	return file, nil
+}
+
+func (file *File) merge(other *File) {
+	if len(file.Default) == 0 {
+		file.Default = other.Default
+	}
+
+	for name, profile := range other.Profiles {
+		if _, ok := file.Profiles[name]; !ok {
+			file.Profiles[name] = profile
+		}
+	}
+}
+
+// tokenFor returns the token of the first profile, by name, that is configured
+// for the given API URL
+func (file *File) tokenFor(apiURL string) string {
+	var names []string
+
+	for name := range file.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		profile := file.Profiles[name]
+
+		if sameURL(profile.APIURL, apiURL) && len(profile.Token) > 0 {
+			return profile.Token
+		}
+	}
+
+	return ""
+}
+
+func sameURL(left string, right string) bool {
+	return strings.TrimSuffix(left, "/") == strings.TrimSuffix(right, "/")
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "config")
+	defer os.RemoveAll(dir)
+
+	t.Run("when the file is TOML", func(t *testing.T) {
+		path := filepath.Join(dir, ".maury.toml")
+		ioutil.WriteFile(path, []byte(`
+default = "staging"
+
+[profiles.staging]
+api_url = "https://api.staging.example.com"
+token = "staging-token"
+`), 0600)
+
+		file, err := ReadFile(path)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it has the profiles", func(t *testing.T) {
+			profile := file.Profiles["staging"]
+
+			if file.Default != "staging" || profile == nil || profile.Token != "staging-token" {
+				t.Errorf("Expected the staging profile, got %v", file)
+			}
+		})
+
+		t.Run("the profiles are named", func(t *testing.T) {
+			if file.Profiles["staging"].Name != "staging" {
+				t.Errorf("Expected the profile to be named")
+			}
+		})
+	})
+
+	t.Run("when the file is YAML with an empty profile", func(t *testing.T) {
+		path := filepath.Join(dir, ".maury.yml")
+		ioutil.WriteFile(path, []byte("profiles:\n  empty:\n  full:\n    token: abc\n"), 0600)
+
+		file, err := ReadFile(path)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it skips the empty profile", func(t *testing.T) {
+			if _, ok := file.Profiles["empty"]; ok {
+				t.Errorf("Expected the empty profile to be skipped")
+			}
+
+			if file.Profiles["full"].Token != "abc" {
+				t.Errorf("Expected the full profile to be read")
+			}
+		})
+	})
+
+	t.Run("when the file does not exist", func(t *testing.T) {
+		_, err := ReadFile(filepath.Join(dir, "nope.yml"))
+
+		t.Run("it is a not-exist error", func(t *testing.T) {
+			if !os.IsNotExist(err) {
+				t.Errorf("Expected a not-exist error, got %v", err)
+			}
+		})
+	})
+}
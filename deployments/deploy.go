@@ -0,0 +1,125 @@
+package deployments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ess/maury/applications"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/requests"
+)
+
+// ErrMissingRef is returned when a deploy is started without a ref to deploy
+var ErrMissingRef = errors.New("A ref is required to start a deploy")
+
+// Deployer provides an interface for the deploy functions to talk to the API
+type Deployer interface {
+	Reader
+	Post(string, url.Values, []byte) ([]byte, error)
+}
+
+// Options describes the optional behavior of a deploy
+type Options struct {
+	// Migrate causes the deploy to run database migrations
+	Migrate bool
+
+	// MigrateCommand overrides the command used to run migrations
+	MigrateCommand string
+
+	// Verbose causes the deploy to produce more detailed output
+	Verbose bool
+}
+
+// Start requests that the given ref of the given application be deployed to
+// the given environment. If there are problems along the way, a non-nil error
+// is returned. Otherwise, the error is nil and the async request that tracks
+// the deploy is returned.
+func Start(driver Deployer, application *applications.Entity, environment *environments.Entity, ref string, options Options) (*requests.Entity, error) {
+	if len(ref) == 0 {
+		return nil, ErrMissingRef
+	}
+
+	wrapped := struct {
+		Deploy map[string]interface{} `json:"deploy"`
+	}{
+		Deploy: map[string]interface{}{
+			"application_id": application.ID,
+			"ref":            ref,
+			"migrate":        options.Migrate,
+			"verbose":        options.Verbose,
+		},
+	}
+
+	if len(options.MigrateCommand) > 0 {
+		wrapped.Deploy["migrate_command"] = options.MigrateCommand
+	}
+
+	data, err := json.Marshal(&wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	pathParts := []string{"environments", environment.ID, "deploy"}
+
+	response, err := driver.Post(strings.Join(pathParts, "/"), nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return requests.Parse(response)
+}
+
+// StartAndWait starts a deploy as Start does, then waits for it to finish
+// within the given context as requests.Wait does, polling at the given
+// interval and reporting progress to the given callback. If the deploy fails, both the deployment and a
+// *requests.FailedError are returned.
+func StartAndWait(ctx context.Context, driver Deployer, application *applications.Entity, environment *environments.Entity, ref string, options Options, interval time.Duration, progress requests.Progress) (*Entity, error) {
+	request, err := Start(driver, application, environment, ref, options)
+	if err != nil {
+		return nil, err
+	}
+
+	finished, err := requests.Wait(ctx, driver, request, interval, progress)
+	if finished == nil {
+		return nil, err
+	}
+
+	deployment, ferr := ForRequest(driver, finished)
+	if ferr != nil {
+		return nil, ferr
+	}
+
+	return deployment, err
+}
+
+// Cancel requests that the given in-progress deployment be cancelled. If there
+// are problems along the way, a non-nil error is returned. Otherwise, the
+// error is nil and the refreshed entity is returned.
+func Cancel(driver Deployer, deployment *Entity) (*Entity, error) {
+	pathParts := []string{"deployments", deployment.ID, "cancel"}
+
+	_, err := driver.Post(strings.Join(pathParts, "/"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return Find(driver, deployment.ID)
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,204 @@
+package deployments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/applications"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+	"github.com/ess/maury/requests"
+)
+
+const started = `{"request" : {"id" : "5", "type" : "deploy", "resource" : "https://api.engineyard.com/deployments/9"}}`
+
+func TestStart(t *testing.T) {
+	application := &applications.Entity{ID: "1"}
+	environment := &environments.Entity{ID: "2"}
+
+	t.Run("when the deploy is accepted", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/deploy", nil, started)
+
+		request, err := Start(driver, application, environment, "master", Options{Migrate: true, MigrateCommand: "rake db:migrate"})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the async request", func(t *testing.T) {
+			if request == nil || request.ID != "5" {
+				t.Errorf("Expected request 5")
+			}
+		})
+
+		t.Run("it sends the deploy options", func(t *testing.T) {
+			calls := driver.Calls()
+			if len(calls) != 1 {
+				t.Fatalf("Expected 1 call, got %d", len(calls))
+			}
+
+			wrapper := struct {
+				Deploy map[string]interface{} `json:"deploy"`
+			}{}
+
+			json.Unmarshal(calls[0].Data, &wrapper)
+
+			if wrapper.Deploy["application_id"] != "1" {
+				t.Errorf("Expected application 1, got %v", wrapper.Deploy["application_id"])
+			}
+
+			if wrapper.Deploy["ref"] != "master" {
+				t.Errorf("Expected ref master, got %v", wrapper.Deploy["ref"])
+			}
+
+			if wrapper.Deploy["migrate"] != true {
+				t.Errorf("Expected migrations to be requested")
+			}
+
+			if wrapper.Deploy["migrate_command"] != "rake db:migrate" {
+				t.Errorf("Unexpected migrate command %v", wrapper.Deploy["migrate_command"])
+			}
+		})
+	})
+
+	t.Run("when no ref is given", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := Start(driver, application, environment, "", Options{})
+
+		t.Run("the error is ErrMissingRef", func(t *testing.T) {
+			if err != ErrMissingRef {
+				t.Errorf("Expected ErrMissingRef, got %v", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environments/2/deploy")
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("POST", "environments/2/deploy", nil, errors.New("nope"))
+
+		request, err := Start(driver, application, environment, "master", Options{})
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("the request is nil", func(t *testing.T) {
+			if request != nil {
+				t.Errorf("Expected a nil request")
+			}
+		})
+	})
+}
+
+func TestStartAndWait(t *testing.T) {
+	application := &applications.Entity{ID: "1"}
+	environment := &environments.Entity{ID: "2"}
+
+	t.Run("when the deploy succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/deploy", nil, started)
+		driver.Respond("GET", "requests/5", nil, `{"request" : {"id" : "5", "successful" : true, "finished_at" : "2018-01-01T00:00:00Z", "resource" : "https://api.engineyard.com/deployments/9"}}`)
+		driver.Respond("GET", "deployments/9", nil, `{"deployment" : {"id" : "9", "successful" : true}}`)
+
+		var seen []*requests.Entity
+		deployment, err := StartAndWait(context.Background(), driver, application, environment, "master", Options{}, 0, func(request *requests.Entity) {
+			seen = append(seen, request)
+		})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it reports progress", func(t *testing.T) {
+			if len(seen) != 1 {
+				t.Errorf("Expected 1 progress report, got %d", len(seen))
+			}
+		})
+
+		t.Run("it returns the deployment", func(t *testing.T) {
+			if deployment == nil || deployment.ID != "9" {
+				t.Errorf("Expected deployment 9")
+			}
+		})
+	})
+
+	t.Run("when the deploy fails", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/deploy", nil, started)
+		driver.Respond("GET", "requests/5", nil, `{"request" : {"id" : "5", "message" : "boom", "finished_at" : "2018-01-01T00:00:00Z", "resource" : "https://api.engineyard.com/deployments/9"}}`)
+		driver.Respond("GET", "deployments/9", nil, `{"deployment" : {"id" : "9"}}`)
+
+		deployment, err := StartAndWait(context.Background(), driver, application, environment, "master", Options{}, 0, nil)
+
+		t.Run("the error is a FailedError", func(t *testing.T) {
+			if _, ok := err.(*requests.FailedError); !ok {
+				t.Errorf("Expected a *requests.FailedError, got %T", err)
+			}
+		})
+
+		t.Run("it still returns the deployment", func(t *testing.T) {
+			if deployment == nil || deployment.ID != "9" {
+				t.Errorf("Expected deployment 9")
+			}
+		})
+	})
+}
+
+func TestCancel(t *testing.T) {
+	deployment := &Entity{ID: "9"}
+
+	t.Run("when the cancellation is accepted", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "deployments/9/cancel", nil, `{}`)
+		driver.Respond("GET", "deployments/9", nil, `{"deployment" : {"id" : "9", "status" : "cancelled"}}`)
+
+		result, err := Cancel(driver, deployment)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the refreshed deployment", func(t *testing.T) {
+			if result == nil || result.Status != "cancelled" {
+				t.Errorf("Expected a cancelled deployment")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("POST", "deployments/9/cancel", nil, errors.New("nope"))
+
+		result, err := Cancel(driver, deployment)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it does not refresh the deployment", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected a nil entity")
+			}
+
+			driver.AssertNotCalled(t, "deployments/9")
+		})
+	})
+}
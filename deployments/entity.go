@@ -0,0 +1,54 @@
+// Package deployments provides the data structures and functions for modeling
+// the Deployments endpoint on the Engine Yard API
+package deployments
+
+import (
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to an upstream Deployment
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Deployment Details
+	Commit            string `json:"commit,omitempty"`
+	Migrate           bool   `json:"migrate,omitempty"`
+	MigrateCommand    string `json:"migrate_command,omitempty"`
+	Ref               string `json:"ref,omitempty"`
+	ResolvedRef       string `json:"resolved_ref,omitempty"`
+	ServersideVersion string `json:"serverside_version,omitempty"`
+	Status            string `json:"status,omitempty"`
+	Successful        bool   `json:"successful,omitempty"`
+
+	// Relation URLs
+	Application string `json:"application,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	Output      string `json:"output,omitempty"`
+	Request     string `json:"request,omitempty"`
+	User        string `json:"user,omitempty"`
+
+	// Timestamps
+	CreatedAt  timestamp.Time `json:"created_at,omitempty"`
+	FinishedAt timestamp.Time `json:"finished_at,omitempty"`
+	StartedAt  timestamp.Time `json:"started_at,omitempty"`
+	UpdatedAt  timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Finished returns true if the deployment has finished, successfully or not
+func (deployment *Entity) Finished() bool {
+	return !deployment.FinishedAt.IsZero()
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
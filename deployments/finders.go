@@ -0,0 +1,102 @@
+package deployments
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/ess/maury/applications"
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/requests"
+)
+
+// Reader provides an interface for the finder functions to talk to the API
+type Reader interface {
+	Get(string, url.Values) ([]byte, error)
+}
+
+// ForApplication returns an array of deployment entities from the API scoped
+// to the given application. If params are provided, they are passed along to
+// the API for consideration. If there are problems along the way, a non-nil
+// error is returned.
+func ForApplication(driver Reader, application *applications.Entity, params url.Values) ([]*Entity, error) {
+	pathParts := []string{"applications", application.ID, "deployments"}
+
+	return allPages(driver, strings.Join(pathParts, "/"), params)
+}
+
+// ForEnvironment returns an array of deployment entities from the API scoped
+// to the given environment. If params are provided, they are passed along to
+// the API for consideration. If there are problems along the way, a non-nil
+// error is returned.
+func ForEnvironment(driver Reader, environment *environments.Entity, params url.Values) ([]*Entity, error) {
+	pathParts := []string{"environments", environment.ID, "deployments"}
+
+	return allPages(driver, strings.Join(pathParts, "/"), params)
+}
+
+// Find queries the API for a single deployment entity by deployment ID. If
+// there are problems along the way, a non-nil error is returned. Otherwise,
+// the error is nil and the entity is populated.
+func Find(driver Reader, id string) (*Entity, error) {
+	response, err := driver.Get("deployments/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper := struct {
+		Deployment *Entity `json:"deployment,omitempty"`
+	}{}
+
+	err = json.Unmarshal(response, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Deployment, nil
+}
+
+// ForRequest queries the API for the deployment that was started by the given
+// request
+func ForRequest(driver Reader, request *requests.Entity) (*Entity, error) {
+	return Find(driver, request.ResourceID())
+}
+
+func allPages(driver Reader, path string, params url.Values) ([]*Entity, error) {
+	var deployments []*Entity
+
+	err := client.EachPage(driver, path, params, func(response []byte) (int, error) {
+		wrapper := struct {
+			Deployments []*Entity `json:"deployments,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(response, &wrapper); err != nil {
+			return 0, err
+		}
+
+		deployments = append(deployments, wrapper.Deployments...)
+
+		return len(wrapper.Deployments), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return deployments, nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,179 @@
+package deployments
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/ess/maury/applications"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+	"github.com/ess/maury/requests"
+)
+
+func TestForApplication(t *testing.T) {
+	application := &applications.Entity{ID: "1"}
+	path := "applications/1/deployments"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"deployments" : [{"id" : "1"}, {"id" : "2"}]}`)
+
+		result, err := ForApplication(driver, application, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the deployments for the application", func(t *testing.T) {
+			if len(result) != 2 {
+				t.Errorf("Expected 2 deployments, got %d", len(result))
+			}
+		})
+	})
+
+	t.Run("when there are several pages", func(t *testing.T) {
+		params := url.Values{}
+		params.Set("environment", "2")
+
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, maurytest.Page(1, params), maurytest.Collection("deployments", 1, 100))
+		driver.Respond("GET", path, maurytest.Page(2, params), maurytest.Collection("deployments", 101, 150))
+
+		result, err := ForApplication(driver, application, params)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it passes the params along with every page", func(t *testing.T) {
+			driver.AssertCalled(t, path, maurytest.Page(1, params))
+			driver.AssertCalled(t, path, maurytest.Page(2, params))
+		})
+
+		t.Run("it returns the deployments from every page", func(t *testing.T) {
+			if len(result) != 150 {
+				t.Errorf("Expected 150 deployments, got %d", len(result))
+			}
+		})
+	})
+}
+
+func TestForEnvironment(t *testing.T) {
+	environment := &environments.Entity{ID: "1"}
+	path := "environments/1/deployments"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"deployments" : [{"id" : "3"}]}`)
+
+		result, err := ForEnvironment(driver, environment, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the deployments for the environment", func(t *testing.T) {
+			if len(result) != 1 || result[0].ID != "3" {
+				t.Errorf("Expected only deployment 3")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", path, nil, errors.New("nope"))
+
+		result, err := ForEnvironment(driver, environment, nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("there are no results", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no results")
+			}
+		})
+
+		t.Run("it does not keep trying", func(t *testing.T) {
+			if calls := driver.CallCount(path); calls != 1 {
+				t.Errorf("Expected 1 call, got %d", calls)
+			}
+		})
+	})
+}
+
+func TestFind(t *testing.T) {
+	t.Run("when the deployment exists", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond(
+			"GET",
+			"deployments/1",
+			nil,
+			`{"deployment" : {"id" : "1", "ref" : "master", "commit" : "abc123", "successful" : true, "finished_at" : "2018-01-01T00:00:00Z"}}`,
+		)
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is populated", func(t *testing.T) {
+			if result == nil || result.Ref != "master" || result.Commit != "abc123" {
+				t.Errorf("Expected deployment of master at abc123")
+			}
+		})
+
+		t.Run("it is finished", func(t *testing.T) {
+			if !result.Finished() {
+				t.Errorf("Expected the deployment to be finished")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "deployments/1", nil, errors.New("nope"))
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+	})
+}
+
+func TestForRequest(t *testing.T) {
+	request := &requests.Entity{Resource: "https://api.engineyard.com/deployments/9"}
+
+	driver := maurytest.NewDriver()
+	driver.Respond("GET", "deployments/9", nil, `{"deployment" : {"id" : "9"}}`)
+
+	result, err := ForRequest(driver, request)
+
+	t.Run("it finds the deployment that the request started", func(t *testing.T) {
+		if err != nil || result == nil || result.ID != "9" {
+			t.Errorf("Expected deployment 9")
+		}
+	})
+}
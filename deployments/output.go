@@ -0,0 +1,92 @@
+package deployments
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrNoOutput is returned when a deployment has no output to fetch, which is
+// the case until it has started
+var ErrNoOutput = errors.New("The deployment has no output")
+
+// ErrForeignOutput is returned when a deployment's output URL is not served
+// by the API that the driver talks to
+var ErrForeignOutput = errors.New("The deployment's output is not served by the API")
+
+// OutputReader provides an interface for Output to talk to the API
+type OutputReader interface {
+	Reader
+	BaseURL() *url.URL
+}
+
+// Output fetches the text that the given deployment has logged so far. The
+// output URL given by the API is fetched through the driver, so it is
+// authenticated like any other request. If the URL is not served by the
+// driver's API, the error is ErrForeignOutput and nothing is fetched, so that
+// credentials are never sent elsewhere. If there are other problems along the
+// way, a non-nil error is returned.
+func Output(driver OutputReader, deployment *Entity) (string, error) {
+	if len(deployment.Output) == 0 {
+		return "", ErrNoOutput
+	}
+
+	link, err := url.Parse(deployment.Output)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := outputPath(driver.BaseURL(), link)
+	if err != nil {
+		return "", err
+	}
+
+	var params url.Values
+	if len(link.RawQuery) > 0 {
+		params = link.Query()
+	}
+
+	response, err := driver.Get(path, params)
+	if err != nil {
+		return "", err
+	}
+
+	return string(response), nil
+}
+
+// outputPath turns the output link into a path relative to the base URL, as
+// the driver expects, so that a base URL path prefix isn't sent twice
+func outputPath(base *url.URL, link *url.URL) (string, error) {
+	prefix := strings.TrimSuffix(base.Path, "/")
+	path := link.Path
+
+	if link.IsAbs() {
+		if !strings.EqualFold(link.Scheme, base.Scheme) || !strings.EqualFold(link.Host, base.Host) {
+			return "", ErrForeignOutput
+		}
+
+		if len(prefix) > 0 && !strings.HasPrefix(path, prefix+"/") {
+			return "", ErrForeignOutput
+		}
+	}
+
+	if len(prefix) > 0 && strings.HasPrefix(path, prefix+"/") {
+		path = strings.TrimPrefix(path, prefix)
+	}
+
+	return strings.TrimPrefix(path, "/"), nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,83 @@
+package deployments
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ess/maury/maurytest"
+)
+
+func TestOutput(t *testing.T) {
+	t.Run("when the deployment has output", func(t *testing.T) {
+		deployment := &Entity{ID: "9", Output: "https://api.engineyard.com/deployments/9/output?format=text"}
+
+		params := url.Values{}
+		params.Set("format", "text")
+
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "deployments/9/output", params, "~> Deploying master\n")
+
+		output, err := Output(driver, deployment)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the output text", func(t *testing.T) {
+			if output != "~> Deploying master\n" {
+				t.Errorf("Unexpected output '%s'", output)
+			}
+		})
+	})
+
+	t.Run("when the API is served beneath a path", func(t *testing.T) {
+		deployment := &Entity{ID: "9", Output: "https://example.com/api/v3/deployments/9/output"}
+
+		driver := maurytest.NewDriver()
+		driver.SetBaseURL(&url.URL{Scheme: "https", Host: "example.com", Path: "/api/v3"})
+		driver.Respond("GET", "deployments/9/output", nil, "~> Deploying master\n")
+
+		_, err := Output(driver, deployment)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it does not repeat the path", func(t *testing.T) {
+			driver.AssertCalled(t, "deployments/9/output", nil)
+		})
+	})
+
+	t.Run("when the output is served by another host", func(t *testing.T) {
+		deployment := &Entity{ID: "9", Output: "https://logs.example.com/deployments/9/output"}
+
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "deployments/9/output", nil, "~> Deploying master\n")
+
+		_, err := Output(driver, deployment)
+
+		t.Run("the error is ErrForeignOutput", func(t *testing.T) {
+			if err != ErrForeignOutput {
+				t.Errorf("Expected ErrForeignOutput, got %v", err)
+			}
+		})
+
+		t.Run("nothing is fetched", func(t *testing.T) {
+			driver.AssertNotCalled(t, "deployments/9/output")
+		})
+	})
+
+	t.Run("when the deployment has no output", func(t *testing.T) {
+		_, err := Output(maurytest.NewDriver(), &Entity{ID: "9"})
+
+		t.Run("the error is ErrNoOutput", func(t *testing.T) {
+			if err != ErrNoOutput {
+				t.Errorf("Expected ErrNoOutput, got %v", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,52 @@
+// Package environments provides the data structures and functions for
+// modeling the Environments endpoint on the Engine Yard API
+package environments
+
+import (
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to an upstream Environment
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Environment Details
+	DatabaseStack    string `json:"database_stack,omitempty"`
+	DeployMethod     string `json:"deploy_method,omitempty"`
+	DeploymentStatus string `json:"deployment_status,omitempty"`
+	FrameworkEnv     string `json:"framework_env,omitempty"`
+	Language         string `json:"language,omitempty"`
+	Name             string `json:"name,omitempty"`
+	Region           string `json:"region,omitempty"`
+	ReleaseLabel     string `json:"release_label,omitempty"`
+	ServiceLevel     string `json:"service_level,omitempty"`
+	StackName        string `json:"stack_name,omitempty"`
+	Username         string `json:"username,omitempty"`
+
+	// Relation URLs
+	Account      string `json:"account,omitempty"`
+	Applications string `json:"applications,omitempty"`
+	Blueprints   string `json:"blueprints,omitempty"`
+	Deployments  string `json:"deployments,omitempty"`
+	Keypairs     string `json:"keypairs,omitempty"`
+	Servers      string `json:"servers,omitempty"`
+
+	// Timestamps
+	CreatedAt timestamp.Time `json:"created_at,omitempty"`
+	DeletedAt timestamp.Time `json:"deleted_at,omitempty"`
+	UpdatedAt timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,91 @@
+package environments
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/client"
+)
+
+// Reader provides an interface for the finder functions to talk to the API
+type Reader interface {
+	Get(string, url.Values) ([]byte, error)
+}
+
+// All returns an array of environment entities from the API. If params are
+// provided, they are passed along to the API for consideration. If there are
+// problems along the way, a non-nil error is returned.
+func All(driver Reader, params url.Values) ([]*Entity, error) {
+	return allPages(driver, "environments", params)
+}
+
+// ForAccount returns an array of environment entities from the API scoped to
+// the given account. If params are provided, they are passed along to the API
+// for consideration. If there are problems along the way, a non-nil error is
+// returned.
+func ForAccount(driver Reader, account *accounts.Entity, params url.Values) ([]*Entity, error) {
+	pathParts := []string{"accounts", account.ID, "environments"}
+
+	return allPages(driver, strings.Join(pathParts, "/"), params)
+}
+
+// Find queries the API for a single environment entity by environment ID. If
+// there are problems along the way, a non-nil error is returned. Otherwise,
+// the error is nil and the entity is populated.
+func Find(driver Reader, id string) (*Entity, error) {
+	response, err := driver.Get("environments/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper := struct {
+		Environment *Entity `json:"environment,omitempty"`
+	}{}
+
+	err = json.Unmarshal(response, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Environment, nil
+}
+
+func allPages(driver Reader, path string, params url.Values) ([]*Entity, error) {
+	var environments []*Entity
+
+	err := client.EachPage(driver, path, params, func(response []byte) (int, error) {
+		wrapper := struct {
+			Environments []*Entity `json:"environments,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(response, &wrapper); err != nil {
+			return 0, err
+		}
+
+		environments = append(environments, wrapper.Environments...)
+
+		return len(wrapper.Environments), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return environments, nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,214 @@
+package environments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ess/maury/requests"
+)
+
+// ErrBootSource is returned when an environment is booted with both or
+// neither of a blueprint and a configuration
+var ErrBootSource = errors.New("Exactly one of a blueprint or a configuration is required to boot an environment")
+
+// InvalidApplyModeError is returned when an environment is applied in a mode
+// that isn't one of the known apply modes
+type InvalidApplyModeError struct {
+	Mode ApplyMode
+}
+
+func (err *InvalidApplyModeError) Error() string {
+	return "Unknown apply mode '" + string(err.Mode) + "'"
+}
+
+// Operator provides an interface for the lifecycle functions to talk to the
+// API
+type Operator interface {
+	Reader
+	Post(string, url.Values, []byte) ([]byte, error)
+}
+
+// Configuration describes the servers to boot for an environment that is not
+// booted from a blueprint
+type Configuration struct {
+	// Type is the layout of the environment: solo, cluster or production
+	Type string `json:"type,omitempty"`
+
+	// AppInstances is the number of application servers to boot
+	AppInstances int `json:"app_instances,omitempty"`
+
+	// DBInstances is the number of database servers to boot
+	DBInstances int `json:"db_instances,omitempty"`
+
+	// Flavor is the instance size of the application servers
+	Flavor string `json:"flavor,omitempty"`
+
+	// DBFlavor is the instance size of the database servers
+	DBFlavor string `json:"db_flavor,omitempty"`
+
+	// IPID is the ID of an existing IP address to attach to the environment
+	IPID string `json:"ip_id,omitempty"`
+}
+
+// BootOptions describes how an environment is booted. Exactly one of
+// BlueprintID and Configuration must be given.
+type BootOptions struct {
+	BlueprintID   string
+	Configuration *Configuration
+}
+
+// ApplyMode describes how much of the Chef run is performed when changes are
+// applied to an environment
+type ApplyMode string
+
+const (
+	// ApplyMain rebuilds the environment, running both the Engine Yard and
+	// the custom Chef recipes
+	ApplyMain ApplyMode = "main"
+
+	// ApplyCustom runs only the custom Chef recipes
+	ApplyCustom ApplyMode = "custom"
+
+	// ApplyQuick runs the Engine Yard recipes without the slower steps that
+	// are only needed when servers are first configured
+	ApplyQuick ApplyMode = "quick"
+)
+
+// Known returns true if the mode is one of the known apply modes
+func (mode ApplyMode) Known() bool {
+	switch mode {
+	case ApplyMain, ApplyCustom, ApplyQuick:
+		return true
+	}
+
+	return false
+}
+
+// Boot requests that the given environment be booted from a blueprint or a
+// configuration. If there are problems along the way, a non-nil error is
+// returned. Otherwise, the error is nil and the async request that tracks the
+// boot is returned.
+func Boot(driver Operator, environment *Entity, options BootOptions) (*requests.Entity, error) {
+	if (len(options.BlueprintID) > 0) == (options.Configuration != nil) {
+		return nil, ErrBootSource
+	}
+
+	cluster := map[string]interface{}{}
+
+	if len(options.BlueprintID) > 0 {
+		cluster["blueprint_id"] = options.BlueprintID
+	} else {
+		cluster["configuration"] = options.Configuration
+	}
+
+	return perform(driver, environment, "boot", map[string]interface{}{"cluster_configuration": cluster})
+}
+
+// Stop requests that all of the servers in the given environment be
+// terminated. If there are problems along the way, a non-nil error is
+// returned. Otherwise, the error is nil and the async request that tracks the
+// shutdown is returned.
+func Stop(driver Operator, environment *Entity) (*requests.Entity, error) {
+	return perform(driver, environment, "deprovision", nil)
+}
+
+// Apply requests that the Chef recipes for the given environment be run in
+// the given mode. If the mode isn't known, the error is an
+// *InvalidApplyModeError. If there are problems along the way, a non-nil
+// error is returned. Otherwise, the error is nil and the async request that
+// tracks the Chef run is returned.
+func Apply(driver Operator, environment *Entity, mode ApplyMode) (*requests.Entity, error) {
+	if !mode.Known() {
+		return nil, &InvalidApplyModeError{Mode: mode}
+	}
+
+	return perform(driver, environment, "apply", map[string]interface{}{"type": mode})
+}
+
+// Upgrade requests that the stack of the given environment be upgraded to the
+// given release label, or to the latest release if the label is empty. If
+// there are problems along the way, a non-nil error is returned. Otherwise,
+// the error is nil and the async request that tracks the upgrade is returned.
+func Upgrade(driver Operator, environment *Entity, releaseLabel string) (*requests.Entity, error) {
+	var body map[string]interface{}
+
+	if len(releaseLabel) > 0 {
+		body = map[string]interface{}{"release_label": releaseLabel}
+	}
+
+	return perform(driver, environment, "upgrade", body)
+}
+
+// BootAndWait boots the given environment as Boot does, then waits for the
+// boot to finish within the given context as requests.Wait does
+func BootAndWait(ctx context.Context, driver Operator, environment *Entity, options BootOptions, interval time.Duration, progress requests.Progress) (*requests.Entity, error) {
+	return wait(ctx, driver, interval, progress)(Boot(driver, environment, options))
+}
+
+// StopAndWait stops the given environment as Stop does, then waits for the
+// shutdown to finish within the given context as requests.Wait does
+func StopAndWait(ctx context.Context, driver Operator, environment *Entity, interval time.Duration, progress requests.Progress) (*requests.Entity, error) {
+	return wait(ctx, driver, interval, progress)(Stop(driver, environment))
+}
+
+// ApplyAndWait applies the given environment as Apply does, then waits for
+// the Chef run to finish within the given context as requests.Wait does
+func ApplyAndWait(ctx context.Context, driver Operator, environment *Entity, mode ApplyMode, interval time.Duration, progress requests.Progress) (*requests.Entity, error) {
+	return wait(ctx, driver, interval, progress)(Apply(driver, environment, mode))
+}
+
+// UpgradeAndWait upgrades the given environment as Upgrade does, then waits
+// for the upgrade to finish within the given context as requests.Wait does
+func UpgradeAndWait(ctx context.Context, driver Operator, environment *Entity, releaseLabel string, interval time.Duration, progress requests.Progress) (*requests.Entity, error) {
+	return wait(ctx, driver, interval, progress)(Upgrade(driver, environment, releaseLabel))
+}
+
+func perform(driver Operator, environment *Entity, action string, body map[string]interface{}) (*requests.Entity, error) {
+	var data []byte
+
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		data = encoded
+	}
+
+	pathParts := []string{"environments", environment.ID, action}
+
+	response, err := driver.Post(strings.Join(pathParts, "/"), nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return requests.Parse(response)
+}
+
+func wait(ctx context.Context, driver Operator, interval time.Duration, progress requests.Progress) func(*requests.Entity, error) (*requests.Entity, error) {
+	return func(request *requests.Entity, err error) (*requests.Entity, error) {
+		if err != nil {
+			return nil, err
+		}
+
+		return requests.Wait(ctx, driver, request, interval, progress)
+	}
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
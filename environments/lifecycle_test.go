@@ -0,0 +1,240 @@
+package environments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/maurytest"
+	"github.com/ess/maury/requests"
+)
+
+const pending = `{"request" : {"id" : "5"}}`
+const finished = `{"request" : {"id" : "5", "successful" : true, "finished_at" : "2018-01-01T00:00:00Z"}}`
+
+func sent(driver *maurytest.Driver) map[string]interface{} {
+	body := make(map[string]interface{})
+
+	calls := driver.Calls()
+	if len(calls) > 0 && calls[0].Data != nil {
+		json.Unmarshal(calls[0].Data, &body)
+	}
+
+	return body
+}
+
+func TestBoot(t *testing.T) {
+	environment := &Entity{ID: "2"}
+
+	t.Run("when booting from a blueprint", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/boot", nil, pending)
+
+		request, err := Boot(driver, environment, BootOptions{BlueprintID: "7"})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the async request", func(t *testing.T) {
+			if request == nil || request.ID != "5" {
+				t.Errorf("Expected request 5")
+			}
+		})
+
+		t.Run("it sends the blueprint", func(t *testing.T) {
+			cluster, _ := sent(driver)["cluster_configuration"].(map[string]interface{})
+
+			if cluster["blueprint_id"] != "7" {
+				t.Errorf("Expected blueprint 7, got %v", cluster["blueprint_id"])
+			}
+		})
+	})
+
+	t.Run("when booting from a configuration", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/boot", nil, pending)
+
+		_, err := Boot(driver, environment, BootOptions{Configuration: &Configuration{Type: "cluster", AppInstances: 2}})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it sends the configuration", func(t *testing.T) {
+			cluster, _ := sent(driver)["cluster_configuration"].(map[string]interface{})
+			configuration, _ := cluster["configuration"].(map[string]interface{})
+
+			if configuration["type"] != "cluster" || configuration["app_instances"] != float64(2) {
+				t.Errorf("Unexpected configuration %v", configuration)
+			}
+		})
+	})
+
+	t.Run("when both a blueprint and a configuration are given", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := Boot(driver, environment, BootOptions{BlueprintID: "7", Configuration: &Configuration{}})
+
+		t.Run("the error is ErrBootSource", func(t *testing.T) {
+			if err != ErrBootSource {
+				t.Errorf("Expected ErrBootSource, got %v", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environments/2/boot")
+		})
+	})
+
+	t.Run("when neither a blueprint nor a configuration is given", func(t *testing.T) {
+		_, err := Boot(maurytest.NewDriver(), environment, BootOptions{})
+
+		t.Run("the error is ErrBootSource", func(t *testing.T) {
+			if err != ErrBootSource {
+				t.Errorf("Expected ErrBootSource, got %v", err)
+			}
+		})
+	})
+}
+
+func TestStop(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("POST", "environments/2/deprovision", nil, pending)
+
+	request, err := Stop(driver, &Entity{ID: "2"})
+
+	t.Run("it deprovisions the environment", func(t *testing.T) {
+		if err != nil || request == nil || request.ID != "5" {
+			t.Errorf("Expected request 5, got error %v", err)
+		}
+	})
+}
+
+func TestApply(t *testing.T) {
+	t.Run("when the mode is known", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/apply", nil, pending)
+
+		_, err := Apply(driver, &Entity{ID: "2"}, ApplyCustom)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it sends the mode", func(t *testing.T) {
+			if sent(driver)["type"] != "custom" {
+				t.Errorf("Expected a custom apply, got %v", sent(driver)["type"])
+			}
+		})
+	})
+
+	for _, mode := range []ApplyMode{"", "full"} {
+		t.Run("when the mode is '"+string(mode)+"'", func(t *testing.T) {
+			driver := maurytest.NewDriver()
+
+			request, err := Apply(driver, &Entity{ID: "2"}, mode)
+
+			t.Run("the error is an InvalidApplyModeError", func(t *testing.T) {
+				if _, ok := err.(*InvalidApplyModeError); !ok || request != nil {
+					t.Errorf("Expected an *InvalidApplyModeError and no request, got %v", err)
+				}
+			})
+
+			t.Run("it does not contact the API", func(t *testing.T) {
+				driver.AssertNotCalled(t, "environments/2/apply")
+			})
+		})
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	t.Run("when a release label is given", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/upgrade", nil, pending)
+
+		Upgrade(driver, &Entity{ID: "2"}, "stable-v5-3.0.40")
+
+		t.Run("it sends the release label", func(t *testing.T) {
+			if sent(driver)["release_label"] != "stable-v5-3.0.40" {
+				t.Errorf("Unexpected release label %v", sent(driver)["release_label"])
+			}
+		})
+	})
+
+	t.Run("when no release label is given", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/upgrade", nil, pending)
+
+		Upgrade(driver, &Entity{ID: "2"}, "")
+
+		t.Run("it sends no body", func(t *testing.T) {
+			if calls := driver.Calls(); len(calls) != 1 || calls[0].Data != nil {
+				t.Errorf("Expected a single call with no body")
+			}
+		})
+	})
+}
+
+func TestApplyAndWait(t *testing.T) {
+	t.Run("when the Chef run finishes", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/apply", nil, pending)
+		driver.Respond("GET", "requests/5", nil, pending)
+
+		polls := 0
+		request, err := ApplyAndWait(context.Background(), driver, &Entity{ID: "2"}, ApplyMain, 0, func(latest *requests.Entity) {
+			polls++
+
+			if polls == 2 {
+				driver.Respond("GET", "requests/5", nil, finished)
+			}
+		})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it reports progress until the request finishes", func(t *testing.T) {
+			if polls != 3 {
+				t.Errorf("Expected 3 progress reports, got %d", polls)
+			}
+		})
+
+		t.Run("it returns the finished request", func(t *testing.T) {
+			if request == nil || !request.Finished() {
+				t.Errorf("Expected a finished request")
+			}
+		})
+	})
+
+	t.Run("when the apply cannot be started", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("POST", "environments/2/apply", nil, errors.New("nope"))
+
+		request, err := ApplyAndWait(context.Background(), driver, &Entity{ID: "2"}, ApplyMain, 0, nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it does not poll", func(t *testing.T) {
+			if request != nil {
+				t.Errorf("Expected a nil request")
+			}
+
+			driver.AssertNotCalled(t, "requests/5")
+		})
+	})
+}
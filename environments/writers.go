@@ -0,0 +1,76 @@
+package environments
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/ess/maury/accounts"
+)
+
+// ErrMissingName is returned when an environment is created without a name
+var ErrMissingName = errors.New("A name is required to create an environment")
+
+// Spec describes an environment to create
+type Spec struct {
+	DatabaseStack string `json:"database_stack,omitempty"`
+	FrameworkEnv  string `json:"framework_env,omitempty"`
+	Language      string `json:"language,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Region        string `json:"region,omitempty"`
+	StackName     string `json:"stack_name,omitempty"`
+}
+
+// Create requests that an environment matching the given spec be created in
+// the given account. The environment has no servers until it is booted. If
+// the spec is nil or has no name, the error is ErrMissingName. If there are
+// other problems along the way, a non-nil error is returned. Otherwise, the
+// error is nil and the created entity is returned.
+func Create(driver Operator, account *accounts.Entity, spec *Spec) (*Entity, error) {
+	if spec == nil || len(spec.Name) == 0 {
+		return nil, ErrMissingName
+	}
+
+	wrapped := struct {
+		Environment *Spec `json:"environment"`
+	}{
+		Environment: spec,
+	}
+
+	data, err := json.Marshal(&wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	pathParts := []string{"accounts", account.ID, "environments"}
+
+	response, err := driver.Post(strings.Join(pathParts, "/"), nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper := struct {
+		Environment *Entity `json:"environment,omitempty"`
+	}{}
+
+	err = json.Unmarshal(response, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Environment, nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
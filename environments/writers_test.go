@@ -0,0 +1,79 @@
+package environments
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/maurytest"
+)
+
+func TestCreate(t *testing.T) {
+	account := &accounts.Entity{ID: "1"}
+
+	t.Run("when the spec is valid", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "accounts/1/environments", nil, `{"environment" : {"id" : "3", "name" : "staging-2"}}`)
+
+		environment, err := Create(driver, account, &Spec{Name: "staging-2", FrameworkEnv: "staging", Region: "us-east-1"})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the created environment", func(t *testing.T) {
+			if environment == nil || environment.ID != "3" {
+				t.Errorf("Expected environment 3")
+			}
+		})
+
+		t.Run("it sends the spec", func(t *testing.T) {
+			spec, _ := sent(driver)["environment"].(map[string]interface{})
+
+			if spec["name"] != "staging-2" || spec["framework_env"] != "staging" || spec["region"] != "us-east-1" {
+				t.Errorf("Unexpected spec %v", spec)
+			}
+		})
+	})
+
+	t.Run("when the spec has no name", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := Create(driver, account, &Spec{Region: "us-east-1"})
+
+		t.Run("the error is ErrMissingName", func(t *testing.T) {
+			if err != ErrMissingName {
+				t.Errorf("Expected ErrMissingName, got %v", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "accounts/1/environments")
+		})
+	})
+
+	t.Run("when there is no spec", func(t *testing.T) {
+		_, err := Create(maurytest.NewDriver(), account, nil)
+
+		t.Run("the error is ErrMissingName", func(t *testing.T) {
+			if err != ErrMissingName {
+				t.Errorf("Expected ErrMissingName, got %v", err)
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("POST", "accounts/1/environments", nil, errors.New("nope"))
+
+		environment, err := Create(driver, account, &Spec{Name: "staging-2"})
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || environment != nil {
+				t.Errorf("Expected an error and no entity")
+			}
+		})
+	})
+}
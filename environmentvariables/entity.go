@@ -0,0 +1,97 @@
+// Package environmentvariables provides the data structures and functions for
+// modeling the Environment Variables endpoint on the Engine Yard API
+package environmentvariables
+
+import (
+	"strings"
+
+	"github.com/ess/maury/applications"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/timestamp"
+)
+
+// Mask is shown in place of the value of a sensitive environment variable
+const Mask = "********"
+
+// Entity is a flat data structure that maps to an upstream Environment
+// Variable
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Environment Variable Details
+	Name      string `json:"name,omitempty"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+	Value     string `json:"value,omitempty"`
+
+	// Relation URLs
+	Application string `json:"application,omitempty"`
+	Environment string `json:"environment,omitempty"`
+
+	// Relation IDs
+	ApplicationID string `json:"application_id,omitempty"`
+	EnvironmentID string `json:"environment_id,omitempty"`
+
+	// Timestamps
+	CreatedAt timestamp.Time `json:"created_at,omitempty"`
+	UpdatedAt timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// SafeValue returns the value of the environment variable, or Mask if the
+// variable is sensitive
+func (variable *Entity) SafeValue() string {
+	if variable.Sensitive {
+		return Mask
+	}
+
+	return variable.Value
+}
+
+// BelongsTo reports whether the environment variable is set for the given
+// application in the given environment, according to its relation IDs or,
+// failing those, its relation URLs. A relation that the API didn't describe
+// at all is assumed to match.
+func (variable *Entity) BelongsTo(application *applications.Entity, environment *environments.Entity) bool {
+	return relates(variable.ApplicationID, variable.Application, application.ID) &&
+		relates(variable.EnvironmentID, variable.Environment, environment.ID)
+}
+
+// Masked returns a copy of the environment variable that is safe to display,
+// with its value replaced by Mask if it is sensitive
+func (variable *Entity) Masked() *Entity {
+	masked := *variable
+	masked.Value = variable.SafeValue()
+
+	return &masked
+}
+
+func relates(id string, link string, expected string) bool {
+	if len(id) == 0 {
+		id = linkID(link)
+	}
+
+	return len(id) == 0 || id == expected
+}
+
+func linkID(link string) string {
+	if len(link) == 0 {
+		return ""
+	}
+
+	parts := strings.Split(strings.TrimRight(link, "/"), "/")
+
+	return parts[len(parts)-1]
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,104 @@
+package environmentvariables
+
+import (
+	"testing"
+)
+
+func TestEntity_Masked(t *testing.T) {
+	t.Run("when the variable is sensitive", func(t *testing.T) {
+		variable := &Entity{Name: "SECRET_KEY_BASE", Value: "hunter2", Sensitive: true}
+		masked := variable.Masked()
+
+		t.Run("the value is masked", func(t *testing.T) {
+			if masked.Value != Mask {
+				t.Errorf("Expected a masked value, got '%s'", masked.Value)
+			}
+		})
+
+		t.Run("the original is untouched", func(t *testing.T) {
+			if variable.Value != "hunter2" {
+				t.Errorf("Expected the original value to be kept")
+			}
+		})
+	})
+
+	t.Run("when the variable is not sensitive", func(t *testing.T) {
+		variable := &Entity{Name: "RAILS_ENV", Value: "production"}
+
+		t.Run("the value is shown", func(t *testing.T) {
+			if variable.Masked().Value != "production" {
+				t.Errorf("Expected 'production', got '%s'", variable.Masked().Value)
+			}
+		})
+	})
+}
+
+func TestEntity_BelongsTo(t *testing.T) {
+	t.Run("when the links match", func(t *testing.T) {
+		variable := &Entity{
+			Application: "https://api.engineyard.com/applications/1",
+			Environment: "https://api.engineyard.com/environments/2/",
+		}
+
+		t.Run("it belongs", func(t *testing.T) {
+			if !variable.BelongsTo(application, environment) {
+				t.Errorf("Expected the variable to belong")
+			}
+		})
+	})
+
+	t.Run("when the links point elsewhere", func(t *testing.T) {
+		variable := &Entity{
+			Application: "https://api.engineyard.com/applications/4",
+			Environment: "https://api.engineyard.com/environments/2",
+		}
+
+		t.Run("it does not belong", func(t *testing.T) {
+			if variable.BelongsTo(application, environment) {
+				t.Errorf("Expected the variable not to belong")
+			}
+		})
+	})
+
+	t.Run("when there are only IDs", func(t *testing.T) {
+		t.Run("and they match", func(t *testing.T) {
+			variable := &Entity{ApplicationID: "1", EnvironmentID: "2"}
+
+			if !variable.BelongsTo(application, environment) {
+				t.Errorf("Expected the variable to belong")
+			}
+		})
+
+		t.Run("and one doesn't match", func(t *testing.T) {
+			variable := &Entity{ApplicationID: "1", EnvironmentID: "3"}
+
+			if variable.BelongsTo(application, environment) {
+				t.Errorf("Expected the variable not to belong")
+			}
+		})
+	})
+
+	t.Run("when the IDs and links disagree", func(t *testing.T) {
+		variable := &Entity{
+			ApplicationID: "4",
+			Application:   "https://api.engineyard.com/applications/1",
+			EnvironmentID: "2",
+		}
+
+		t.Run("the IDs win", func(t *testing.T) {
+			if variable.BelongsTo(application, environment) {
+				t.Errorf("Expected the variable not to belong")
+			}
+		})
+	})
+
+	t.Run("when the relations are missing", func(t *testing.T) {
+		variable := &Entity{}
+
+		t.Run("it trusts the API's scoping", func(t *testing.T) {
+			if !variable.BelongsTo(application, environment) {
+				t.Errorf("Expected the variable to belong")
+			}
+		})
+	})
+}
@@ -0,0 +1,139 @@
+package environmentvariables
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/ess/maury/applications"
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/environments"
+)
+
+// Reader provides an interface for the finder functions to talk to the API
+type Reader interface {
+	Get(string, url.Values) ([]byte, error)
+}
+
+// NotFoundError is returned when an application has no environment variable
+// with the requested name in an environment
+type NotFoundError struct {
+	Name string
+}
+
+func (err *NotFoundError) Error() string {
+	return "No environment variable named " + err.Name
+}
+
+// All returns an array of environment variable entities from the API. If
+// params are provided, they are passed along to the API for consideration. If
+// there are problems along the way, a non-nil error is returned.
+func All(driver Reader, params url.Values) ([]*Entity, error) {
+	return allPages(driver, "environment_variables", params)
+}
+
+// ForApplicationEnvironment returns an array of environment variable entities
+// from the API that are set for the given application in the given
+// environment. The API is asked to filter the variables, and any that it
+// returns for another application or environment are dropped. If there are
+// problems along the way, a non-nil error is returned.
+func ForApplicationEnvironment(driver Reader, application *applications.Entity, environment *environments.Entity) ([]*Entity, error) {
+	params := url.Values{}
+	params.Set("application", application.ID)
+	params.Set("environment", environment.ID)
+
+	candidates, err := allPages(driver, "environment_variables", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var variables []*Entity
+
+	for _, variable := range candidates {
+		if variable.BelongsTo(application, environment) {
+			variables = append(variables, variable)
+		}
+	}
+
+	return variables, nil
+}
+
+// Find queries the API for a single environment variable entity by ID. If
+// there are problems along the way, a non-nil error is returned. Otherwise,
+// the error is nil and the entity is populated.
+func Find(driver Reader, id string) (*Entity, error) {
+	response, err := driver.Get("environment_variables/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(response)
+}
+
+// Get returns the environment variable with the given name for the given
+// application in the given environment. If there is no such variable, the
+// error is a *NotFoundError.
+func Get(driver Reader, application *applications.Entity, environment *environments.Entity, name string) (*Entity, error) {
+	variables, err := ForApplicationEnvironment(driver, application, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, variable := range variables {
+		if variable.Name == name {
+			return variable, nil
+		}
+	}
+
+	return nil, &NotFoundError{Name: name}
+}
+
+func parse(response []byte) (*Entity, error) {
+	wrapper := struct {
+		EnvironmentVariable *Entity `json:"environment_variable,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.EnvironmentVariable, nil
+}
+
+func allPages(driver Reader, path string, params url.Values) ([]*Entity, error) {
+	var variables []*Entity
+
+	err := client.EachPage(driver, path, params, func(response []byte) (int, error) {
+		wrapper := struct {
+			EnvironmentVariables []*Entity `json:"environment_variables,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(response, &wrapper); err != nil {
+			return 0, err
+		}
+
+		variables = append(variables, wrapper.EnvironmentVariables...)
+
+		return len(wrapper.EnvironmentVariables), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return variables, nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,139 @@
+package environmentvariables
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/applications"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+)
+
+var application = &applications.Entity{ID: "1"}
+var environment = &environments.Entity{ID: "2"}
+
+// links is the relation URLs for variables that belong to application and
+// environment
+const links = `"application" : "https://api.engineyard.com/applications/1", "environment" : "https://api.engineyard.com/environments/2"`
+
+const listing = `{"environment_variables" : [
+	{"id" : "10", "name" : "RAILS_ENV", "value" : "production", ` + links + `},
+	{"id" : "11", "name" : "SECRET_KEY_BASE", "value" : "hunter2", "sensitive" : true, ` + links + `}
+]}`
+
+func TestForApplicationEnvironment(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("GET", "environment_variables", nil, listing)
+
+	result, err := ForApplicationEnvironment(driver, application, environment)
+
+	t.Run("it has no error", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it returns the variables", func(t *testing.T) {
+		if len(result) != 2 {
+			t.Errorf("Expected 2 variables, got %d", len(result))
+		}
+	})
+
+	t.Run("it filters by application and environment", func(t *testing.T) {
+		calls := driver.Calls()
+		if len(calls) != 1 {
+			t.Fatalf("Expected 1 call, got %d", len(calls))
+		}
+
+		params := calls[0].Params
+		if params.Get("application") != "1" || params.Get("environment") != "2" {
+			t.Errorf("Unexpected params %v", params)
+		}
+	})
+
+	t.Run("when the API ignores the filter", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "environment_variables", nil, `{"environment_variables" : [
+			{"id" : "10", "name" : "RAILS_ENV", "value" : "production", `+links+`},
+			{"id" : "20", "name" : "RAILS_ENV", "value" : "staging", "application" : "https://api.engineyard.com/applications/1", "environment" : "https://api.engineyard.com/environments/3"},
+			{"id" : "30", "name" : "NODE_ENV", "value" : "production", "application" : "https://api.engineyard.com/applications/4", "environment" : "https://api.engineyard.com/environments/2"}
+		]}`)
+
+		result, _ := ForApplicationEnvironment(driver, application, environment)
+
+		t.Run("it drops the variables for other applications and environments", func(t *testing.T) {
+			if len(result) != 1 || result[0].ID != "10" {
+				t.Errorf("Expected only variable 10")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "environment_variables", nil, errors.New("nope"))
+
+		result, err := ForApplicationEnvironment(driver, application, environment)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("there are no results", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no results")
+			}
+		})
+	})
+}
+
+func TestGet(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("GET", "environment_variables", nil, listing)
+
+	t.Run("when the variable exists", func(t *testing.T) {
+		variable, err := Get(driver, application, environment, "RAILS_ENV")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the variable", func(t *testing.T) {
+			if variable == nil || variable.ID != "10" {
+				t.Errorf("Expected variable 10")
+			}
+		})
+	})
+
+	t.Run("when the variable does not exist", func(t *testing.T) {
+		variable, err := Get(driver, application, environment, "DATABASE_URL")
+
+		t.Run("the error is a NotFoundError", func(t *testing.T) {
+			if _, ok := err.(*NotFoundError); !ok {
+				t.Errorf("Expected a *NotFoundError, got %T", err)
+			}
+		})
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if variable != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+	})
+}
+
+func TestFind(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("GET", "environment_variables/10", nil, `{"environment_variable" : {"id" : "10", "name" : "RAILS_ENV"}}`)
+
+	variable, err := Find(driver, "10")
+
+	t.Run("it returns the variable", func(t *testing.T) {
+		if err != nil || variable == nil || variable.Name != "RAILS_ENV" {
+			t.Errorf("Expected RAILS_ENV, got error %v", err)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package environmentvariables
+
+import (
+	"sort"
+
+	"github.com/ess/maury/applications"
+	"github.com/ess/maury/environments"
+)
+
+// Action describes what Sync did to an environment variable
+type Action string
+
+const (
+	// Created means that the variable did not exist and was created
+	Created Action = "created"
+
+	// Updated means that the value of the variable was changed
+	Updated Action = "updated"
+
+	// Deleted means that the variable was not desired and was removed
+	Deleted Action = "deleted"
+)
+
+// Change describes a single change made by Sync. The values of sensitive
+// variables are replaced by Mask, so a Change is always safe to display.
+type Change struct {
+	Action   Action
+	Name     string
+	OldValue string
+	NewValue string
+}
+
+// Sync makes the environment variables for the given application in the given
+// environment match the desired names and values, creating, updating and
+// deleting as few variables as possible. New variables are not sensitive, and
+// updated variables keep their sensitivity. If the API masks the value of a
+// sensitive variable, there's no telling whether it already matches, so it is
+// left alone rather than updated on every run. The changes are made in name
+// order, and the ones that were made are returned even if a later change
+// fails, in which case the error is non-nil. Only variables that belong to
+// the given application and environment are ever updated or deleted, even if
+// the API lists others.
+func Sync(driver Writer, application *applications.Entity, environment *environments.Entity, desired map[string]string) ([]*Change, error) {
+	variables, err := ForApplicationEnvironment(driver, application, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]*Entity)
+	for _, variable := range variables {
+		existing[variable.Name] = variable
+	}
+
+	var changes []*Change
+
+	for _, name := range sortedNames(desired, existing) {
+		value, wanted := desired[name]
+		variable, present := existing[name]
+
+		var change *Change
+		var err error
+
+		switch {
+		case wanted && !present:
+			change = &Change{Action: Created, Name: name, NewValue: value}
+			_, err = Create(driver, application, environment, name, value, false)
+
+		case wanted && !withheld(variable) && variable.Value != value:
+			change = &Change{Action: Updated, Name: name, OldValue: variable.SafeValue(), NewValue: value}
+			if variable.Sensitive {
+				change.NewValue = Mask
+			}
+
+			_, err = Update(driver, variable, value)
+
+		case !wanted:
+			change = &Change{Action: Deleted, Name: name, OldValue: variable.SafeValue()}
+			err = Delete(driver, variable)
+
+		default:
+			continue
+		}
+
+		if err != nil {
+			return changes, err
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// withheld reports whether the API masked the value of a sensitive variable
+func withheld(variable *Entity) bool {
+	return variable.Sensitive && (len(variable.Value) == 0 || variable.Value == Mask)
+}
+
+func sortedNames(desired map[string]string, existing map[string]*Entity) []string {
+	var names []string
+
+	for name := range desired {
+		names = append(names, name)
+	}
+
+	for name := range existing {
+		if _, ok := desired[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,195 @@
+package environmentvariables
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/maurytest"
+)
+
+func TestSync(t *testing.T) {
+	existing := `{"environment_variables" : [
+		{"id" : "10", "name" : "RAILS_ENV", "value" : "production", ` + links + `},
+		{"id" : "11", "name" : "SECRET_KEY_BASE", "value" : "hunter2", "sensitive" : true, ` + links + `},
+		{"id" : "13", "name" : "OLD_FLAG", "value" : "1", ` + links + `}
+	]}`
+
+	desired := map[string]string{
+		"RAILS_ENV":       "production",
+		"SECRET_KEY_BASE": "correct horse",
+		"NEW_FLAG":        "1",
+	}
+
+	t.Run("when every change succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "environment_variables", nil, existing)
+		driver.Respond("POST", "environment_variables", nil, `{"environment_variable" : {"id" : "14"}}`)
+		driver.Respond("PUT", "environment_variables/11", nil, `{"environment_variable" : {"id" : "11"}}`)
+		driver.Respond("DELETE", "environment_variables/13", nil, ``)
+
+		changes, err := Sync(driver, application, environment, desired)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it reports the changes in name order", func(t *testing.T) {
+			if len(changes) != 3 {
+				t.Fatalf("Expected 3 changes, got %d", len(changes))
+			}
+
+			expected := []struct {
+				action Action
+				name   string
+			}{
+				{Created, "NEW_FLAG"},
+				{Deleted, "OLD_FLAG"},
+				{Updated, "SECRET_KEY_BASE"},
+			}
+
+			for i, change := range changes {
+				if change.Action != expected[i].action || change.Name != expected[i].name {
+					t.Errorf("Expected %s %s, got %s %s", expected[i].action, expected[i].name, change.Action, change.Name)
+				}
+			}
+		})
+
+		t.Run("it masks sensitive values in the report", func(t *testing.T) {
+			if changes[2].OldValue != Mask || changes[2].NewValue != Mask {
+				t.Errorf("Expected masked values, got '%s' and '%s'", changes[2].OldValue, changes[2].NewValue)
+			}
+		})
+
+		t.Run("it leaves unchanged variables alone", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environment_variables/10")
+		})
+	})
+
+	t.Run("when a change fails", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "environment_variables", nil, existing)
+		driver.Respond("POST", "environment_variables", nil, `{"environment_variable" : {"id" : "14"}}`)
+		driver.Fail("DELETE", "environment_variables/13", nil, errors.New("nope"))
+
+		changes, err := Sync(driver, application, environment, desired)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it reports the changes made before the failure", func(t *testing.T) {
+			if len(changes) != 1 || changes[0].Name != "NEW_FLAG" {
+				t.Errorf("Expected only the NEW_FLAG change")
+			}
+		})
+
+		t.Run("it stops at the failure", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environment_variables/11")
+		})
+	})
+
+	t.Run("when the API describes variables by ID only", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "environment_variables", nil, `{"environment_variables" : [
+			{"id" : "10", "name" : "RAILS_ENV", "value" : "staging", "application_id" : "1", "environment_id" : "2"},
+			{"id" : "20", "name" : "DATABASE_URL", "value" : "postgres://db", "application_id" : "4", "environment_id" : "2"}
+		]}`)
+		driver.Respond("PUT", "environment_variables/10", nil, `{"environment_variable" : {"id" : "10"}}`)
+
+		changes, err := Sync(driver, application, environment, map[string]string{"RAILS_ENV": "production"})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it updates the existing variable instead of creating it", func(t *testing.T) {
+			if len(changes) != 1 || changes[0].Action != Updated {
+				t.Fatalf("Expected a single update, got %d changes", len(changes))
+			}
+
+			if driver.CallCount("environment_variables/10") != 1 {
+				t.Errorf("Expected variable 10 to be updated")
+			}
+		})
+
+		t.Run("it leaves the other application's variables alone", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environment_variables/20")
+		})
+	})
+
+	t.Run("when the API masks a sensitive value", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "environment_variables", nil, `{"environment_variables" : [
+			{"id" : "11", "name" : "SECRET_KEY_BASE", "value" : "********", "sensitive" : true, `+links+`}
+		]}`)
+
+		changes, err := Sync(driver, application, environment, map[string]string{"SECRET_KEY_BASE": "hunter2"})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it leaves the variable alone", func(t *testing.T) {
+			if len(changes) != 0 {
+				t.Errorf("Expected no changes, got %d", len(changes))
+			}
+
+			driver.AssertNotCalled(t, "environment_variables/11")
+		})
+	})
+
+	t.Run("when the API lists another application's variables", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "environment_variables", nil, `{"environment_variables" : [
+			{"id" : "10", "name" : "RAILS_ENV", "value" : "production", `+links+`},
+			{"id" : "20", "name" : "DATABASE_URL", "value" : "postgres://db", "application" : "https://api.engineyard.com/applications/4", "environment" : "https://api.engineyard.com/environments/2"},
+			{"id" : "21", "name" : "RAILS_ENV", "value" : "staging", "application" : "https://api.engineyard.com/applications/4", "environment" : "https://api.engineyard.com/environments/2"}
+		]}`)
+
+		changes, err := Sync(driver, application, environment, map[string]string{"RAILS_ENV": "production"})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it makes no changes", func(t *testing.T) {
+			if len(changes) != 0 {
+				t.Errorf("Expected no changes, got %d", len(changes))
+			}
+		})
+
+		t.Run("it leaves the other application's variables alone", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environment_variables/20")
+			driver.AssertNotCalled(t, "environment_variables/21")
+		})
+	})
+
+	t.Run("when the variables can't be listed", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "environment_variables", nil, errors.New("nope"))
+
+		changes, err := Sync(driver, application, environment, desired)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("it makes no changes", func(t *testing.T) {
+			if len(changes) != 0 || len(driver.Calls()) != 1 {
+				t.Errorf("Expected nothing but the listing")
+			}
+		})
+	})
+}
@@ -0,0 +1,93 @@
+package environmentvariables
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/ess/maury/applications"
+	"github.com/ess/maury/environments"
+)
+
+// Writer provides an interface for the functions that change environment
+// variables to talk to the API
+type Writer interface {
+	Reader
+	Post(string, url.Values, []byte) ([]byte, error)
+	Put(string, url.Values, []byte) ([]byte, error)
+	Delete(string, url.Values) ([]byte, error)
+}
+
+// Create requests that an environment variable with the given name and value
+// be set for the given application in the given environment. If there are
+// issues along the way, a non-nil error is returned. Otherwise, the error is
+// nil and the created entity is returned.
+func Create(driver Writer, application *applications.Entity, environment *environments.Entity, name string, value string, sensitive bool) (*Entity, error) {
+	wrapped := struct {
+		EnvironmentVariable map[string]interface{} `json:"environment_variable"`
+	}{
+		EnvironmentVariable: map[string]interface{}{
+			"application_id": application.ID,
+			"environment_id": environment.ID,
+			"name":           name,
+			"value":          value,
+			"sensitive":      sensitive,
+		},
+	}
+
+	data, err := json.Marshal(&wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := driver.Post("environment_variables", nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(response)
+}
+
+// Update requests that the given environment variable be changed to the given
+// value. If there are issues along the way, a non-nil error is returned.
+// Otherwise, the error is nil and the updated entity is returned.
+func Update(driver Writer, variable *Entity, value string) (*Entity, error) {
+	wrapped := struct {
+		EnvironmentVariable map[string]interface{} `json:"environment_variable"`
+	}{
+		EnvironmentVariable: map[string]interface{}{"value": value},
+	}
+
+	data, err := json.Marshal(&wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := driver.Put("environment_variables/"+variable.ID, nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(response)
+}
+
+// Delete requests that the given environment variable be removed. If there
+// are issues along the way, a non-nil error is returned.
+func Delete(driver Writer, variable *Entity) error {
+	_, err := driver.Delete("environment_variables/"+variable.ID, nil)
+
+	return err
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
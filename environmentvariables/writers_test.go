@@ -0,0 +1,84 @@
+package environmentvariables
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/maurytest"
+)
+
+func TestCreate(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("POST", "environment_variables", nil, `{"environment_variable" : {"id" : "12", "name" : "API_KEY"}}`)
+
+	variable, err := Create(driver, application, environment, "API_KEY", "abc", true)
+
+	t.Run("it has no error", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it returns the created variable", func(t *testing.T) {
+		if variable == nil || variable.ID != "12" {
+			t.Errorf("Expected variable 12")
+		}
+	})
+
+	t.Run("it sends the variable", func(t *testing.T) {
+		wrapper := struct {
+			EnvironmentVariable map[string]interface{} `json:"environment_variable"`
+		}{}
+
+		json.Unmarshal(driver.Calls()[0].Data, &wrapper)
+		sent := wrapper.EnvironmentVariable
+
+		if sent["application_id"] != "1" || sent["environment_id"] != "2" {
+			t.Errorf("Expected application 1 and environment 2, got %v", sent)
+		}
+
+		if sent["name"] != "API_KEY" || sent["value"] != "abc" || sent["sensitive"] != true {
+			t.Errorf("Unexpected variable %v", sent)
+		}
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("PUT", "environment_variables/10", nil, `{"environment_variable" : {"id" : "10", "value" : "staging"}}`)
+
+	variable, err := Update(driver, &Entity{ID: "10"}, "staging")
+
+	t.Run("it returns the updated variable", func(t *testing.T) {
+		if err != nil || variable == nil || variable.Value != "staging" {
+			t.Errorf("Expected the value to be staging, got error %v", err)
+		}
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("when the API accepts the deletion", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("DELETE", "environment_variables/10", nil, ``)
+
+		err := Delete(driver, &Entity{ID: "10"})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("DELETE", "environment_variables/10", nil, errors.New("nope"))
+
+		t.Run("it has an error", func(t *testing.T) {
+			if Delete(driver, &Entity{ID: "10"}) == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
@@ -0,0 +1,50 @@
+// Package events provides the data structures and functions for modeling
+// the Server Events endpoint on the Engine Yard API. Events record the things
+// that have happened to a server over its lifetime, such as being
+// provisioned, rebooted or resized.
+package events
+
+import (
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to an upstream Server Event
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Event Details
+	Message     string `json:"message,omitempty"`
+	TriggeredBy string `json:"triggered_by,omitempty"`
+	Type        string `json:"type,omitempty"`
+
+	// Relation URLs
+	Environment string `json:"environment,omitempty"`
+	Server      string `json:"server,omitempty"`
+
+	// Timestamps
+	CreatedAt timestamp.Time `json:"created_at,omitempty"`
+}
+
+// key identifies the event for de-duplication, falling back to its contents
+// for events that have no ID
+func (event *Entity) key() string {
+	if len(event.ID) > 0 {
+		return event.ID
+	}
+
+	return event.CreatedAt.String() + " " + event.Type + " " + event.Message
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
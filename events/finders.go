@@ -0,0 +1,108 @@
+package events
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/servers"
+)
+
+// Reader provides an interface for the finder functions to talk to the API
+type Reader interface {
+	Get(string, url.Values) ([]byte, error)
+}
+
+// ForServer returns an array of event entities from the API for the given
+// server that happened within the given window, oldest first. A nil window
+// returns all of the server's events. If there are problems along the way, a
+// non-nil error is returned.
+func ForServer(driver Reader, server *servers.Entity, window *Window) ([]*Entity, error) {
+	pathParts := []string{"servers", server.ID, "events"}
+
+	return windowed(driver, strings.Join(pathParts, "/"), window)
+}
+
+// ForEnvironment returns an array of event entities from the API for all of
+// the servers in the given environment that happened within the given window,
+// oldest first. A nil window returns all of the environment's events. If there
+// are problems along the way, a non-nil error is returned.
+func ForEnvironment(driver Reader, environment *environments.Entity, window *Window) ([]*Entity, error) {
+	pathParts := []string{"environments", environment.ID, "events"}
+
+	return windowed(driver, strings.Join(pathParts, "/"), window)
+}
+
+// Find queries the API for a single event entity by event ID. If
+// there are problems along the way, a non-nil error is returned. Otherwise,
+// the error is nil and the entity is populated.
+func Find(driver Reader, id string) (*Entity, error) {
+	response, err := driver.Get("events/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(response)
+}
+
+func parse(response []byte) (*Entity, error) {
+	wrapper := struct {
+		Event *Entity `json:"event,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Event, nil
+}
+
+func windowed(driver Reader, path string, window *Window) ([]*Entity, error) {
+	events, err := allPages(driver, path, window.Params())
+	if err != nil {
+		return nil, err
+	}
+
+	return window.Filter(events), nil
+}
+
+func allPages(driver Reader, path string, params url.Values) ([]*Entity, error) {
+	var events []*Entity
+
+	err := client.EachPage(driver, path, params, func(response []byte) (int, error) {
+		wrapper := struct {
+			Events []*Entity `json:"events,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(response, &wrapper); err != nil {
+			return 0, err
+		}
+
+		events = append(events, wrapper.Events...)
+
+		return len(wrapper.Events), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
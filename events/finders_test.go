@@ -0,0 +1,103 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+	"github.com/ess/maury/servers"
+)
+
+const history = `{"events" : [
+	{"id" : "2", "type" : "reboot", "message" : "Rebooted", "triggered_by" : "bob@example.com", "created_at" : "2018-01-02T00:00:00Z"},
+	{"id" : "1", "type" : "provision", "message" : "Provisioned", "triggered_by" : "system", "created_at" : "2018-01-01T00:00:00Z"}
+]}`
+
+func TestForServer(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("GET", "servers/1/events", nil, history)
+
+	t.Run("when there is no window", func(t *testing.T) {
+		result, err := ForServer(driver, &servers.Entity{ID: "1"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns every event, oldest first", func(t *testing.T) {
+			if len(result) != 2 || result[0].Type != "provision" || result[1].TriggeredBy != "bob@example.com" {
+				t.Errorf("Expected the provision and then the reboot")
+			}
+		})
+	})
+
+	t.Run("when there is a window", func(t *testing.T) {
+		since := time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)
+		result, _ := ForServer(driver, &servers.Entity{ID: "1"}, &Window{Since: since})
+
+		t.Run("it asks the API for the window", func(t *testing.T) {
+			calls := driver.Calls()
+			last := calls[len(calls)-1]
+
+			if last.Params.Get("since") != "2018-01-02T00:00:00Z" {
+				t.Errorf("Unexpected params %v", last.Params)
+			}
+		})
+
+		t.Run("it filters out events that the API included anyway", func(t *testing.T) {
+			if len(result) != 1 || result[0].ID != "2" {
+				t.Errorf("Expected only event 2")
+			}
+		})
+	})
+}
+
+func TestForEnvironment(t *testing.T) {
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "environments/2/events", nil, history)
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the events for the environment", func(t *testing.T) {
+			if len(result) != 2 {
+				t.Errorf("Expected 2 events, got %d", len(result))
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "environments/2/events", nil, errors.New("nope"))
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("there are no results", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no results")
+			}
+		})
+
+		t.Run("it does not keep trying", func(t *testing.T) {
+			if calls := driver.CallCount("environments/2/events"); calls != 1 {
+				t.Errorf("Expected 1 call, got %d", calls)
+			}
+		})
+	})
+}
@@ -0,0 +1,114 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/servers"
+)
+
+// Feed lists the events that happened within a window, oldest first. The
+// context should bound any requests that the feed makes.
+type Feed func(ctx context.Context, window *Window) ([]*Entity, error)
+
+// ServerFeed returns a Feed of the events for the given server. If the driver
+// is a *client.Driver, each poll is made within the feed's context.
+func ServerFeed(driver Reader, server *servers.Entity) Feed {
+	return func(ctx context.Context, window *Window) ([]*Entity, error) {
+		return ForServer(bind(ctx, driver), server, window)
+	}
+}
+
+// EnvironmentFeed returns a Feed of the events for all of the servers in the
+// given environment. If the driver is a *client.Driver, each poll is made
+// within the feed's context.
+func EnvironmentFeed(driver Reader, environment *environments.Entity) Feed {
+	return func(ctx context.Context, window *Window) ([]*Entity, error) {
+		return ForEnvironment(bind(ctx, driver), environment, window)
+	}
+}
+
+func bind(ctx context.Context, driver Reader) Reader {
+	if bound, ok := driver.(*client.Driver); ok {
+		return bound.WithContext(ctx)
+	}
+
+	return driver
+}
+
+// Follow polls the given feed at the given interval, starting immediately,
+// and delivers each event that happened at or after since on the returned
+// channel, oldest first. Each event is delivered once, even if it is listed by
+// several polls. A poll that fails is skipped, and the next one picks up
+// where the last successful poll left off. If failed isn't nil, it is called
+// with the error from each failed poll, so that the caller can give up by
+// cancelling the context. The channel is closed once the context is done.
+func Follow(ctx context.Context, feed Feed, since time.Time, interval time.Duration, failed func(error)) <-chan *Entity {
+	delivered := make(chan *Entity)
+
+	go func() {
+		defer close(delivered)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// Only the events at the latest timestamp need to be remembered, as
+		// later polls never list anything older
+		latest := since
+		seen := make(map[string]bool)
+
+		for {
+			// A failed poll lists nothing, so the same window is asked for
+			// again on the next tick
+			events, err := feed(ctx, &Window{Since: latest})
+			if err != nil && failed != nil && ctx.Err() == nil {
+				failed(err)
+			}
+
+			for _, event := range events {
+				happened := event.CreatedAt.Time
+
+				if happened.Before(latest) || seen[event.key()] {
+					continue
+				}
+
+				if happened.After(latest) {
+					latest = happened
+					seen = make(map[string]bool)
+				}
+
+				seen[event.key()] = true
+
+				select {
+				case delivered <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return delivered
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,276 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/maurytest"
+	"github.com/ess/maury/servers"
+)
+
+type feed struct {
+	lock     sync.Mutex
+	events   []*Entity
+	polls    int
+	failures int
+}
+
+func (f *feed) fail(polls int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.failures = polls
+}
+
+func (f *feed) add(events ...*Entity) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.events = append(f.events, events...)
+}
+
+func (f *feed) list(ctx context.Context, window *Window) ([]*Entity, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.polls++
+
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("Bad Gateway")
+	}
+
+	return window.Filter(f.events), nil
+}
+
+func (f *feed) count() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.polls
+}
+
+func receive(t *testing.T, events <-chan *Entity, count int) []*Entity {
+	var received []*Entity
+
+	timeout := time.After(2 * time.Second)
+
+	for len(received) < count {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-timeout:
+			t.Fatalf("Expected %d events, got %d", count, len(received))
+		}
+	}
+
+	return received
+}
+
+func TestFollow(t *testing.T) {
+	t.Run("when events arrive over several polls", func(t *testing.T) {
+		source := &feed{}
+		source.add(
+			&Entity{ID: "0", CreatedAt: at("2017-12-31T00:00:00Z")},
+			&Entity{ID: "1", CreatedAt: at("2018-01-01T00:00:00Z")},
+			&Entity{ID: "2", CreatedAt: at("2018-01-02T00:00:00Z")},
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		since := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+		events := Follow(ctx, source.list, since, time.Millisecond, nil)
+
+		first := receive(t, events, 2)
+
+		source.add(
+			&Entity{ID: "3", CreatedAt: at("2018-01-02T00:00:00Z")},
+			&Entity{ID: "4", CreatedAt: at("2018-01-03T00:00:00Z")},
+		)
+
+		second := receive(t, events, 2)
+
+		for source.count() < 5 {
+			time.Sleep(time.Millisecond)
+		}
+
+		cancel()
+
+		var extra []*Entity
+		for event := range events {
+			extra = append(extra, event)
+		}
+
+		t.Run("it skips events from before since", func(t *testing.T) {
+			if first[0].ID != "1" || first[1].ID != "2" {
+				t.Errorf("Expected events 1 and 2, got %s and %s", first[0].ID, first[1].ID)
+			}
+		})
+
+		t.Run("it delivers new events as they appear", func(t *testing.T) {
+			if second[0].ID != "3" || second[1].ID != "4" {
+				t.Errorf("Expected events 3 and 4, got %s and %s", second[0].ID, second[1].ID)
+			}
+		})
+
+		t.Run("it never delivers an event twice", func(t *testing.T) {
+			if len(extra) != 0 {
+				t.Errorf("Expected no more events, got %d", len(extra))
+			}
+		})
+	})
+
+	t.Run("when the context is cancelled", func(t *testing.T) {
+		source := &feed{}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events := Follow(ctx, source.list, time.Time{}, time.Hour, nil)
+
+		cancel()
+
+		t.Run("the channel is closed", func(t *testing.T) {
+			select {
+			case _, open := <-events:
+				if open {
+					t.Errorf("Expected the channel to be closed")
+				}
+			case <-time.After(2 * time.Second):
+				t.Errorf("Expected the channel to be closed")
+			}
+		})
+	})
+
+	t.Run("when polls fail", func(t *testing.T) {
+		source := &feed{}
+		source.add(&Entity{ID: "1", CreatedAt: at("2018-01-01T00:00:00Z")})
+		source.fail(3)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var lock sync.Mutex
+		var failures []error
+
+		events := Follow(ctx, source.list, time.Time{}, time.Millisecond, func(err error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			failures = append(failures, err)
+		})
+
+		received := receive(t, events, 1)
+
+		t.Run("it keeps polling until the feed recovers", func(t *testing.T) {
+			if source.count() < 4 {
+				t.Errorf("Expected at least 4 polls, got %d", source.count())
+			}
+		})
+
+		t.Run("it reports each failure", func(t *testing.T) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			if len(failures) != 3 {
+				t.Errorf("Expected 3 failures, got %d", len(failures))
+			}
+		})
+
+		t.Run("it delivers the events once the feed recovers", func(t *testing.T) {
+			if received[0].ID != "1" {
+				t.Errorf("Expected event 1, got %s", received[0].ID)
+			}
+		})
+	})
+
+	t.Run("when the token has been revoked", func(t *testing.T) {
+		api := maurytest.NewServer("sekrit")
+		defer api.Close()
+
+		driver, _ := client.New(api.URL, "revoked")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var lock sync.Mutex
+		var failures []error
+
+		events := Follow(ctx, ServerFeed(driver, &servers.Entity{ID: "1"}), time.Time{}, time.Millisecond, func(err error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			failures = append(failures, err)
+			if len(failures) == 3 {
+				cancel()
+			}
+		})
+
+		closed := false
+
+		select {
+		case _, open := <-events:
+			closed = !open
+		case <-time.After(2 * time.Second):
+		}
+
+		t.Run("it reports the failures", func(t *testing.T) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			if len(failures) != 3 {
+				t.Fatalf("Expected 3 failures, got %d", len(failures))
+			}
+
+			if apiErr, ok := failures[0].(*client.APIError); !ok || apiErr.StatusCode != http.StatusUnauthorized {
+				t.Errorf("Expected a 401, got %v", failures[0])
+			}
+		})
+
+		t.Run("the caller can give up", func(t *testing.T) {
+			if !closed {
+				t.Errorf("Expected the channel to be closed")
+			}
+		})
+	})
+
+	t.Run("when a poll hangs", func(t *testing.T) {
+		polled := make(chan bool, 1)
+		release := make(chan bool)
+
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case polled <- true:
+			default:
+			}
+
+			<-release
+		}))
+		defer api.Close()
+		defer close(release)
+
+		driver, _ := client.New(api.URL, "sekrit")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events := Follow(ctx, ServerFeed(driver, &servers.Entity{ID: "1"}), time.Time{}, time.Millisecond, nil)
+
+		<-polled
+		cancel()
+
+		t.Run("cancelling the context abandons the poll", func(t *testing.T) {
+			select {
+			case _, open := <-events:
+				if open {
+					t.Errorf("Expected the channel to be closed")
+				}
+			case <-time.After(2 * time.Second):
+				t.Errorf("Expected the channel to be closed")
+			}
+		})
+	})
+}
@@ -0,0 +1,97 @@
+package events
+
+import (
+	"net/url"
+	"sort"
+	"time"
+)
+
+// Window is a span of time to list events for. A zero Since or Until leaves
+// that end of the window open. Since is inclusive and Until is exclusive.
+type Window struct {
+	Since time.Time
+	Until time.Time
+}
+
+// Params returns the window as params for the API
+func (window *Window) Params() url.Values {
+	params := url.Values{}
+
+	if window == nil {
+		return params
+	}
+
+	if !window.Since.IsZero() {
+		params.Set("since", window.Since.UTC().Format(time.RFC3339Nano))
+	}
+
+	if !window.Until.IsZero() {
+		params.Set("until", window.Until.UTC().Format(time.RFC3339Nano))
+	}
+
+	return params
+}
+
+// Contains returns true if the given event happened within the window. A nil
+// window contains every event.
+func (window *Window) Contains(event *Entity) bool {
+	if window == nil {
+		return true
+	}
+
+	happened := event.CreatedAt.Time
+
+	if !window.Since.IsZero() && happened.Before(window.Since) {
+		return false
+	}
+
+	if !window.Until.IsZero() && !happened.Before(window.Until) {
+		return false
+	}
+
+	return true
+}
+
+// Filter returns the given events that happened within the window, oldest
+// first
+func (window *Window) Filter(events []*Entity) []*Entity {
+	var filtered []*Entity
+
+	for _, event := range events {
+		if window.Contains(event) {
+			filtered = append(filtered, event)
+		}
+	}
+
+	sort.Stable(chronological(filtered))
+
+	return filtered
+}
+
+type chronological []*Entity
+
+func (events chronological) Len() int {
+	return len(events)
+}
+
+func (events chronological) Less(i, j int) bool {
+	return events[i].CreatedAt.Before(events[j].CreatedAt.Time)
+}
+
+func (events chronological) Swap(i, j int) {
+	events[i], events[j] = events[j], events[i]
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
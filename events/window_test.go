@@ -0,0 +1,76 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ess/maury/timestamp"
+)
+
+func at(value string) timestamp.Time {
+	parsed, _ := timestamp.Parse(value)
+
+	return parsed
+}
+
+func TestWindow_Params(t *testing.T) {
+	t.Run("when both ends are set", func(t *testing.T) {
+		window := &Window{
+			Since: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			Until: time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+		}
+
+		params := window.Params()
+
+		t.Run("it includes both", func(t *testing.T) {
+			if params.Get("since") != "2018-01-01T00:00:00Z" || params.Get("until") != "2018-01-02T00:00:00Z" {
+				t.Errorf("Unexpected params %v", params)
+			}
+		})
+	})
+
+	t.Run("when the window is nil", func(t *testing.T) {
+		var window *Window
+
+		t.Run("there are no params", func(t *testing.T) {
+			if len(window.Params()) != 0 {
+				t.Errorf("Expected no params")
+			}
+		})
+	})
+}
+
+func TestWindow_Filter(t *testing.T) {
+	events := []*Entity{
+		{ID: "3", CreatedAt: at("2018-01-03T00:00:00Z")},
+		{ID: "1", CreatedAt: at("2018-01-01T00:00:00Z")},
+		{ID: "2", CreatedAt: at("2018-01-02T00:00:00Z")},
+	}
+
+	t.Run("when the window is bounded", func(t *testing.T) {
+		window := &Window{
+			Since: time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+			Until: time.Date(2018, 1, 3, 0, 0, 0, 0, time.UTC),
+		}
+
+		filtered := window.Filter(events)
+
+		t.Run("since is inclusive and until is exclusive", func(t *testing.T) {
+			if len(filtered) != 1 || filtered[0].ID != "2" {
+				t.Errorf("Expected only event 2")
+			}
+		})
+	})
+
+	t.Run("when the window is nil", func(t *testing.T) {
+		var window *Window
+
+		filtered := window.Filter(events)
+
+		t.Run("it keeps every event, oldest first", func(t *testing.T) {
+			if len(filtered) != 3 || filtered[0].ID != "1" || filtered[2].ID != "3" {
+				t.Errorf("Expected events 1, 2 and 3 in order")
+			}
+		})
+	})
+}
@@ -0,0 +1,41 @@
+// Package keypairs provides the data structures and functions for modeling
+// the Keypairs endpoint on the Engine Yard API. Keypairs are the SSH public
+// keys that are installed on the servers in an environment.
+package keypairs
+
+import (
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to an upstream Keypair
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Keypair Details
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Name        string `json:"name,omitempty"`
+	PublicKey   string `json:"public_key,omitempty"`
+
+	// Relation URLs
+	Environments string `json:"environments,omitempty"`
+	User         string `json:"user,omitempty"`
+
+	// Timestamps
+	CreatedAt timestamp.Time `json:"created_at,omitempty"`
+	DeletedAt timestamp.Time `json:"deleted_at,omitempty"`
+	UpdatedAt timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,106 @@
+package keypairs
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/users"
+)
+
+// Reader provides an interface for the finder functions to talk to the API
+type Reader interface {
+	Get(string, url.Values) ([]byte, error)
+}
+
+// All returns an array of keypair entities from the API. If params are
+// provided, they are passed along to the API for consideration. If there are
+// problems along the way, a non-nil error is returned.
+func All(driver Reader, params url.Values) ([]*Entity, error) {
+	return allPages(driver, "keypairs", params)
+}
+
+// ForUser returns an array of keypair entities from the API that belong to the
+// given user. If params are provided, they are passed along to the API for
+// consideration. If there are problems along the way, a non-nil error is
+// returned.
+func ForUser(driver Reader, user *users.Entity, params url.Values) ([]*Entity, error) {
+	pathParts := []string{"users", user.ID, "keypairs"}
+
+	return allPages(driver, strings.Join(pathParts, "/"), params)
+}
+
+// ForEnvironment returns an array of keypair entities from the API that are
+// attached to the given environment. If params are provided, they are passed
+// along to the API for consideration. If there are problems along the way, a
+// non-nil error is returned.
+func ForEnvironment(driver Reader, environment *environments.Entity, params url.Values) ([]*Entity, error) {
+	pathParts := []string{"environments", environment.ID, "keypairs"}
+
+	return allPages(driver, strings.Join(pathParts, "/"), params)
+}
+
+// Find queries the API for a single keypair entity by keypair ID. If
+// there are problems along the way, a non-nil error is returned. Otherwise,
+// the error is nil and the entity is populated.
+func Find(driver Reader, id string) (*Entity, error) {
+	response, err := driver.Get("keypairs/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(response)
+}
+
+func parse(response []byte) (*Entity, error) {
+	wrapper := struct {
+		Keypair *Entity `json:"keypair,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Keypair, nil
+}
+
+func allPages(driver Reader, path string, params url.Values) ([]*Entity, error) {
+	var keypairs []*Entity
+
+	err := client.EachPage(driver, path, params, func(response []byte) (int, error) {
+		wrapper := struct {
+			Keypairs []*Entity `json:"keypairs,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(response, &wrapper); err != nil {
+			return 0, err
+		}
+
+		keypairs = append(keypairs, wrapper.Keypairs...)
+
+		return len(wrapper.Keypairs), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keypairs, nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,142 @@
+package keypairs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+	"github.com/ess/maury/users"
+)
+
+func TestForUser(t *testing.T) {
+	path := "users/1/keypairs"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"keypairs" : [{"id" : "1"}, {"id" : "2"}]}`)
+
+		result, err := ForUser(driver, &users.Entity{ID: "1"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the keypairs for the user", func(t *testing.T) {
+			if len(result) != 2 {
+				t.Errorf("Expected 2 keypairs")
+			}
+		})
+	})
+
+	t.Run("when there are several pages", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, maurytest.Page(1, nil), maurytest.Collection("keypairs", 1, 100))
+		driver.Respond("GET", path, maurytest.Page(2, nil), maurytest.Collection("keypairs", 101, 102))
+
+		result, err := ForUser(driver, &users.Entity{ID: "1"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the keypairs from every page", func(t *testing.T) {
+			if len(result) != 102 {
+				t.Errorf("Expected 102 keypairs, got %d", len(result))
+			}
+		})
+	})
+}
+
+func TestForEnvironment(t *testing.T) {
+	path := "environments/2/keypairs"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"keypairs" : [{"id" : "3"}]}`)
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the keypairs attached to the environment", func(t *testing.T) {
+			if len(result) != 1 || result[0].ID != "3" {
+				t.Errorf("Expected only keypair 3")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, maurytest.Page(1, nil), maurytest.Collection("keypairs", 1, 100))
+		driver.Fail("GET", path, maurytest.Page(2, nil), errors.New("nope"))
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("there are no results", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no results")
+			}
+		})
+
+		t.Run("it stops requesting pages", func(t *testing.T) {
+			if calls := driver.CallCount(path); calls != 2 {
+				t.Errorf("Expected 2 calls, got %d", calls)
+			}
+		})
+	})
+}
+
+func TestFind(t *testing.T) {
+	t.Run("when the keypair exists", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "keypairs/1", nil, `{"keypair" : {"id" : "1", "name" : "laptop", "fingerprint" : "`+md5Fingerprint+`"}}`)
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is populated", func(t *testing.T) {
+			if result == nil || result.Name != "laptop" || result.Fingerprint != md5Fingerprint {
+				t.Errorf("Expected keypair 'laptop'")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "keypairs/1", nil, errors.New("nope"))
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+	})
+}
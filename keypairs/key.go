@@ -0,0 +1,78 @@
+package keypairs
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// InvalidKeyError is returned when a line cannot be parsed as an SSH public
+// key in authorized_keys format
+type InvalidKeyError struct {
+	Reason string
+}
+
+func (err *InvalidKeyError) Error() string {
+	return "Invalid SSH public key: " + err.Reason
+}
+
+// Key is an SSH public key that has been parsed locally
+type Key struct {
+	// Name is the comment from the authorized_keys line, if any
+	Name string
+
+	// PublicKey is the key in authorized_keys format, without options or a
+	// comment
+	PublicKey string
+
+	// Fingerprint is the MD5 fingerprint of the key, as colon-separated hex
+	Fingerprint string
+
+	// SHA256Fingerprint is the SHA256 fingerprint of the key, as unpadded
+	// base64 with a "SHA256:" prefix
+	SHA256Fingerprint string
+}
+
+// ParseAuthorizedKey parses a single line in authorized_keys format, which may
+// include options and a comment. If the line does not contain a valid public
+// key, the error is an *InvalidKeyError.
+func ParseAuthorizedKey(line string) (*Key, error) {
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) == 0 {
+		return nil, &InvalidKeyError{Reason: "the line is empty"}
+	}
+
+	publicKey, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(trimmed))
+	if err != nil {
+		return nil, &InvalidKeyError{Reason: err.Error()}
+	}
+
+	return &Key{
+		Name:              comment,
+		PublicKey:         strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+		Fingerprint:       ssh.FingerprintLegacyMD5(publicKey),
+		SHA256Fingerprint: ssh.FingerprintSHA256(publicKey),
+	}, nil
+}
+
+// Matches returns true if the given fingerprint is the fingerprint of the key
+// in either the MD5 or the SHA256 format. An "MD5:" prefix is ignored.
+func (key *Key) Matches(fingerprint string) bool {
+	fingerprint = strings.TrimPrefix(strings.TrimSpace(fingerprint), "MD5:")
+
+	return strings.EqualFold(fingerprint, key.Fingerprint) || fingerprint == key.SHA256Fingerprint
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
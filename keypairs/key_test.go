@@ -0,0 +1,91 @@
+package keypairs
+
+import (
+	"testing"
+)
+
+const publicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAf1e6SyfxWVjEYlbJhtda/cz3lJbDAzsi/ug/Xz0HH6"
+const md5Fingerprint = "0c:73:e8:76:29:5c:f7:e9:70:69:c4:40:f1:6a:cd:e4"
+const sha256Fingerprint = "SHA256:WUTIZ+f6+LJFRrVOryOG6Zx7Df7XOPM3z+6297Xduyk"
+
+func TestParseAuthorizedKey(t *testing.T) {
+	t.Run("when the line is a valid key", func(t *testing.T) {
+		key, err := ParseAuthorizedKey(`no-pty,command="echo hi" ` + publicKey + " bob@laptop\n")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("the comment is the name", func(t *testing.T) {
+			if key.Name != "bob@laptop" {
+				t.Errorf("Expected bob@laptop, got '%s'", key.Name)
+			}
+		})
+
+		t.Run("the options and comment are stripped from the key", func(t *testing.T) {
+			if key.PublicKey != publicKey {
+				t.Errorf("Unexpected public key '%s'", key.PublicKey)
+			}
+		})
+
+		t.Run("it is fingerprinted", func(t *testing.T) {
+			if key.Fingerprint != md5Fingerprint {
+				t.Errorf("Expected %s, got %s", md5Fingerprint, key.Fingerprint)
+			}
+
+			if key.SHA256Fingerprint != sha256Fingerprint {
+				t.Errorf("Expected %s, got %s", sha256Fingerprint, key.SHA256Fingerprint)
+			}
+		})
+	})
+
+	t.Run("when the line is not a key", func(t *testing.T) {
+		key, err := ParseAuthorizedKey("ssh-ed25519 not-base64")
+
+		t.Run("the error is an InvalidKeyError", func(t *testing.T) {
+			if _, ok := err.(*InvalidKeyError); !ok {
+				t.Errorf("Expected an *InvalidKeyError, got %T", err)
+			}
+		})
+
+		t.Run("the key is nil", func(t *testing.T) {
+			if key != nil {
+				t.Errorf("Expected a nil key")
+			}
+		})
+	})
+
+	t.Run("when the line is empty", func(t *testing.T) {
+		_, err := ParseAuthorizedKey("   ")
+
+		t.Run("the error is an InvalidKeyError", func(t *testing.T) {
+			if _, ok := err.(*InvalidKeyError); !ok {
+				t.Errorf("Expected an *InvalidKeyError, got %T", err)
+			}
+		})
+	})
+}
+
+func TestKey_Matches(t *testing.T) {
+	key, _ := ParseAuthorizedKey(publicKey)
+
+	t.Run("it matches the MD5 fingerprint in any case", func(t *testing.T) {
+		if !key.Matches("MD5:0C:73:E8:76:29:5C:F7:E9:70:69:C4:40:F1:6A:CD:E4") {
+			t.Errorf("Expected the MD5 fingerprint to match")
+		}
+	})
+
+	t.Run("it matches the SHA256 fingerprint", func(t *testing.T) {
+		if !key.Matches(sha256Fingerprint) {
+			t.Errorf("Expected the SHA256 fingerprint to match")
+		}
+	})
+
+	t.Run("it does not match other fingerprints", func(t *testing.T) {
+		if key.Matches("00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00") {
+			t.Errorf("Expected the fingerprint not to match")
+		}
+	})
+}
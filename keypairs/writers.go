@@ -0,0 +1,131 @@
+package keypairs
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/ess/maury/environments"
+)
+
+// ErrMissingName is returned when a keypair is created without a name and the
+// key has no comment to use as one
+var ErrMissingName = errors.New("A name is required to create a keypair")
+
+// Writer provides an interface for the functions that change keypairs to
+// talk to the API
+type Writer interface {
+	Reader
+	Post(string, url.Values, []byte) ([]byte, error)
+	Delete(string, url.Values) ([]byte, error)
+}
+
+// FingerprintMismatchError is returned when the fingerprint that the API
+// reports for a new keypair does not match the key that was sent
+type FingerprintMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (err *FingerprintMismatchError) Error() string {
+	return "Expected the keypair fingerprint to be " + err.Expected + ", but the API reported " + err.Actual
+}
+
+// Create requests that a keypair be created for the current user from the
+// given authorized_keys line. If name is empty, the comment from the line is
+// used as the name. The key is parsed and fingerprinted locally before it is
+// sent, and if it is invalid, the error is an *InvalidKeyError and no request
+// is sent to the API. If the API reports a different fingerprint, both the
+// created entity and a *FingerprintMismatchError are returned. Otherwise, the
+// error is nil and the created entity is returned.
+func Create(driver Writer, name string, line string) (*Entity, error) {
+	key, err := ParseAuthorizedKey(line)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(name) == 0 {
+		name = key.Name
+	}
+
+	if len(name) == 0 {
+		return nil, ErrMissingName
+	}
+
+	wrapped := struct {
+		Keypair map[string]string `json:"keypair"`
+	}{
+		Keypair: map[string]string{
+			"name":       name,
+			"public_key": key.PublicKey,
+		},
+	}
+
+	data, err := json.Marshal(&wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := driver.Post("keypairs", nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	keypair, err := parse(response)
+	if err != nil {
+		return nil, err
+	}
+
+	if keypair != nil && len(keypair.Fingerprint) > 0 && !key.Matches(keypair.Fingerprint) {
+		return keypair, &FingerprintMismatchError{Expected: key.Fingerprint, Actual: keypair.Fingerprint}
+	}
+
+	return keypair, nil
+}
+
+// Delete requests that the given keypair be removed. If there are issues along
+// the way, a non-nil error is returned.
+func Delete(driver Writer, keypair *Entity) error {
+	_, err := driver.Delete("keypairs/"+keypair.ID, nil)
+
+	return err
+}
+
+// Attach requests that the given keypair be installed on the servers in the
+// given environment. If there are issues along the way, a non-nil error is
+// returned.
+func Attach(driver Writer, keypair *Entity, environment *environments.Entity) error {
+	_, err := driver.Post(attachmentPath(keypair, environment), nil, nil)
+
+	return err
+}
+
+// Detach requests that the given keypair be removed from the servers in the
+// given environment. If there are issues along the way, a non-nil error is
+// returned.
+func Detach(driver Writer, keypair *Entity, environment *environments.Entity) error {
+	_, err := driver.Delete(attachmentPath(keypair, environment), nil)
+
+	return err
+}
+
+func attachmentPath(keypair *Entity, environment *environments.Entity) string {
+	pathParts := []string{"environments", environment.ID, "keypairs", keypair.ID}
+
+	return strings.Join(pathParts, "/")
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
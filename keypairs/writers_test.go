@@ -0,0 +1,128 @@
+package keypairs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+)
+
+func TestCreate(t *testing.T) {
+	t.Run("when the key is valid", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "keypairs", nil, `{"keypair" : {"id" : "1", "name" : "bob@laptop", "fingerprint" : "`+md5Fingerprint+`"}}`)
+
+		keypair, err := Create(driver, "", publicKey+" bob@laptop")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the created keypair", func(t *testing.T) {
+			if keypair == nil || keypair.ID != "1" {
+				t.Errorf("Expected keypair 1")
+			}
+		})
+
+		t.Run("it sends the normalized key named by its comment", func(t *testing.T) {
+			wrapper := struct {
+				Keypair map[string]string `json:"keypair"`
+			}{}
+
+			json.Unmarshal(driver.Calls()[0].Data, &wrapper)
+
+			if wrapper.Keypair["name"] != "bob@laptop" || wrapper.Keypair["public_key"] != publicKey {
+				t.Errorf("Unexpected keypair %v", wrapper.Keypair)
+			}
+		})
+	})
+
+	t.Run("when the key is invalid", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := Create(driver, "laptop", "ssh-rsa garbage")
+
+		t.Run("the error is an InvalidKeyError", func(t *testing.T) {
+			if _, ok := err.(*InvalidKeyError); !ok {
+				t.Errorf("Expected an *InvalidKeyError, got %T", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "keypairs")
+		})
+	})
+
+	t.Run("when the key has no name", func(t *testing.T) {
+		_, err := Create(maurytest.NewDriver(), "", publicKey)
+
+		t.Run("the error is ErrMissingName", func(t *testing.T) {
+			if err != ErrMissingName {
+				t.Errorf("Expected ErrMissingName, got %v", err)
+			}
+		})
+	})
+
+	t.Run("when the API reports a different fingerprint", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "keypairs", nil, `{"keypair" : {"id" : "1", "fingerprint" : "00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00"}}`)
+
+		keypair, err := Create(driver, "laptop", publicKey)
+
+		t.Run("the error is a FingerprintMismatchError", func(t *testing.T) {
+			if _, ok := err.(*FingerprintMismatchError); !ok {
+				t.Errorf("Expected a *FingerprintMismatchError, got %T", err)
+			}
+		})
+
+		t.Run("the created keypair is still returned", func(t *testing.T) {
+			if keypair == nil || keypair.ID != "1" {
+				t.Errorf("Expected keypair 1")
+			}
+		})
+	})
+}
+
+func TestDelete(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("DELETE", "keypairs/1", nil, ``)
+
+	err := Delete(driver, &Entity{ID: "1"})
+
+	t.Run("it deletes the keypair", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+
+		driver.AssertCalled(t, "keypairs/1", nil)
+	})
+}
+
+func TestAttach(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("POST", "environments/2/keypairs/1", nil, ``)
+
+	err := Attach(driver, &Entity{ID: "1"}, &environments.Entity{ID: "2"})
+
+	t.Run("it attaches the keypair to the environment", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+}
+
+func TestDetach(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("DELETE", "environments/2/keypairs/1", nil, ``)
+
+	err := Detach(driver, &Entity{ID: "1"}, &environments.Entity{ID: "2"})
+
+	t.Run("it detaches the keypair from the environment", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+// Package loadbalancers provides the data structures and functions for
+// modeling the Load Balancers endpoint on the Engine Yard API. Load balancers
+// are the provider load balancers (such as ELBs and ALBs) that sit in front
+// of the servers in an environment.
+package loadbalancers
+
+import (
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to an upstream Load Balancer
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Load Balancer Details
+	DNSName       string       `json:"dns_name,omitempty"`
+	HealthCheck   *HealthCheck `json:"health_check,omitempty"`
+	Listeners     []*Listener  `json:"listeners,omitempty"`
+	Name          string       `json:"name,omitempty"`
+	ProvisionedID string       `json:"provisioned_id,omitempty"`
+	ServerIDs     []string     `json:"server_ids,omitempty"`
+
+	// Relation URLs
+	Account     string `json:"account,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	Servers     string `json:"servers,omitempty"`
+
+	// Timestamps
+	CreatedAt timestamp.Time `json:"created_at,omitempty"`
+	DeletedAt timestamp.Time `json:"deleted_at,omitempty"`
+	UpdatedAt timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Listener describes a port that a load balancer accepts traffic on and how
+// that traffic is passed to the servers behind it
+type Listener struct {
+	InstancePort     int    `json:"instance_port,omitempty"`
+	InstanceProtocol string `json:"instance_protocol,omitempty"`
+	LoadBalancerPort int    `json:"load_balancer_port,omitempty"`
+	Protocol         string `json:"protocol,omitempty"`
+	SSLCertificateID string `json:"ssl_certificate_id,omitempty"`
+}
+
+// HealthCheck describes how a load balancer decides whether the servers
+// behind it are healthy. Intervals and timeouts are in seconds.
+type HealthCheck struct {
+	HealthyThreshold   int    `json:"healthy_threshold,omitempty"`
+	Interval           int    `json:"interval,omitempty"`
+	Target             string `json:"target,omitempty"`
+	Timeout            int    `json:"timeout,omitempty"`
+	UnhealthyThreshold int    `json:"unhealthy_threshold,omitempty"`
+}
+
+// Listener returns the listener for the given load balancer port, or nil if
+// there is no such listener
+func (balancer *Entity) Listener(port int) *Listener {
+	for _, listener := range balancer.Listeners {
+		if listener.LoadBalancerPort == port {
+			return listener
+		}
+	}
+
+	return nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
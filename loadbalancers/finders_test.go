@@ -0,0 +1,142 @@
+package loadbalancers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+)
+
+func TestForAccount(t *testing.T) {
+	path := "accounts/1/load_balancers"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"load_balancers" : [{"id" : "1"}, {"id" : "2"}]}`)
+
+		result, err := ForAccount(driver, &accounts.Entity{ID: "1"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the load balancers in the account", func(t *testing.T) {
+			if len(result) != 2 {
+				t.Errorf("Expected 2 load balancers")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, maurytest.Page(1, nil), maurytest.Collection("load_balancers", 1, 100))
+		driver.Fail("GET", path, maurytest.Page(2, nil), errors.New("nope"))
+
+		result, err := ForAccount(driver, &accounts.Entity{ID: "1"}, nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("there are no results", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no results")
+			}
+		})
+
+		t.Run("it stops requesting pages", func(t *testing.T) {
+			if calls := driver.CallCount(path); calls != 2 {
+				t.Errorf("Expected 2 calls, got %d", calls)
+			}
+		})
+	})
+}
+
+func TestForEnvironment(t *testing.T) {
+	path := "environments/2/load_balancers"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"load_balancers" : [{"id" : "3"}]}`)
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the load balancers in the environment", func(t *testing.T) {
+			if len(result) != 1 || result[0].ID != "3" {
+				t.Errorf("Expected only load balancer 3")
+			}
+		})
+	})
+}
+
+func TestFind(t *testing.T) {
+	t.Run("when the load balancer exists", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "load_balancers/1", nil, `{"load_balancer" : {
+			"id" : "1",
+			"name" : "web",
+			"provisioned_id" : "web-lb",
+			"dns_name" : "web-lb.elb.amazonaws.com",
+			"listeners" : [
+				{"load_balancer_port" : 80, "instance_port" : 80, "protocol" : "HTTP"},
+				{"load_balancer_port" : 443, "instance_port" : 80, "protocol" : "HTTPS", "ssl_certificate_id" : "7"}
+			],
+			"health_check" : {"target" : "HTTP:80/health", "interval" : 30},
+			"server_ids" : ["4", "5"]
+		}}`)
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is populated", func(t *testing.T) {
+			if result == nil || result.DNSName != "web-lb.elb.amazonaws.com" || len(result.ServerIDs) != 2 {
+				t.Fatalf("Expected load balancer web-lb with 2 servers")
+			}
+
+			if result.HealthCheck == nil || result.HealthCheck.Target != "HTTP:80/health" {
+				t.Errorf("Expected a health check")
+			}
+		})
+
+		t.Run("its listeners can be looked up by port", func(t *testing.T) {
+			listener := result.Listener(443)
+			if listener == nil || listener.SSLCertificateID != "7" {
+				t.Errorf("Expected the HTTPS listener")
+			}
+
+			if result.Listener(8080) != nil {
+				t.Errorf("Expected no listener on 8080")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "load_balancers/1", nil, errors.New("nope"))
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || result != nil {
+				t.Errorf("Expected an error and no entity")
+			}
+		})
+	})
+}
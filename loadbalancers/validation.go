@@ -0,0 +1,113 @@
+package loadbalancers
+
+import (
+	"strconv"
+	"strings"
+)
+
+// InvalidListenerError is returned when a listener can't be sent to the API
+// because it is incomplete or inconsistent
+type InvalidListenerError struct {
+	Listener *Listener
+	Reason   string
+}
+
+func (err *InvalidListenerError) Error() string {
+	if err.Listener == nil {
+		return "Invalid listener: " + err.Reason
+	}
+
+	return "Invalid listener on port " + strconv.Itoa(err.Listener.LoadBalancerPort) + ": " + err.Reason
+}
+
+// NoListenerError is returned when a load balancer has no listener on the
+// requested port
+type NoListenerError struct {
+	LoadBalancer *Entity
+	Port         int
+}
+
+func (err *NoListenerError) Error() string {
+	return "Load balancer " + err.LoadBalancer.ID + " has no listener on port " + strconv.Itoa(err.Port)
+}
+
+var protocols = map[string]bool{
+	"HTTP":  true,
+	"HTTPS": true,
+	"SSL":   true,
+	"TCP":   true,
+}
+
+// Validate checks that the listener has valid ports and protocols, and that
+// it has an SSL certificate if and only if it terminates SSL. If it doesn't,
+// the error is an *InvalidListenerError. A nil listener is invalid, too.
+func (listener *Listener) Validate() error {
+	invalid := func(reason string) error {
+		return &InvalidListenerError{Listener: listener, Reason: reason}
+	}
+
+	if listener == nil {
+		return invalid("the listener is missing")
+	}
+
+	if !validPort(listener.LoadBalancerPort) || !validPort(listener.InstancePort) {
+		return invalid("ports must be between 1 and 65535")
+	}
+
+	protocol := strings.ToUpper(listener.Protocol)
+	if !protocols[protocol] {
+		return invalid("unknown protocol '" + listener.Protocol + "'")
+	}
+
+	if len(listener.InstanceProtocol) > 0 && !protocols[strings.ToUpper(listener.InstanceProtocol)] {
+		return invalid("unknown instance protocol '" + listener.InstanceProtocol + "'")
+	}
+
+	secure := protocol == "HTTPS" || protocol == "SSL"
+
+	if secure && len(listener.SSLCertificateID) == 0 {
+		return invalid(protocol + " listeners require an SSL certificate")
+	}
+
+	if !secure && len(listener.SSLCertificateID) > 0 {
+		return invalid(protocol + " listeners can't use an SSL certificate")
+	}
+
+	return nil
+}
+
+func validateListeners(listeners []*Listener) error {
+	seen := make(map[int]bool)
+
+	for _, listener := range listeners {
+		if err := listener.Validate(); err != nil {
+			return err
+		}
+
+		if seen[listener.LoadBalancerPort] {
+			return &InvalidListenerError{Listener: listener, Reason: "the port is used by another listener"}
+		}
+
+		seen[listener.LoadBalancerPort] = true
+	}
+
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port < 65536
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,58 @@
+package loadbalancers
+
+import (
+	"testing"
+)
+
+func TestListener_Validate(t *testing.T) {
+	valid := []*Listener{
+		{LoadBalancerPort: 80, InstancePort: 80, Protocol: "HTTP"},
+		{LoadBalancerPort: 443, InstancePort: 80, Protocol: "https", InstanceProtocol: "HTTP", SSLCertificateID: "7"},
+		{LoadBalancerPort: 5432, InstancePort: 5432, Protocol: "TCP"},
+	}
+
+	invalid := map[string]*Listener{
+		"a missing port":               {LoadBalancerPort: 80, Protocol: "HTTP"},
+		"a port out of range":          {LoadBalancerPort: 70000, InstancePort: 80, Protocol: "HTTP"},
+		"an unknown protocol":          {LoadBalancerPort: 80, InstancePort: 80, Protocol: "UDP"},
+		"an unknown instance protocol": {LoadBalancerPort: 80, InstancePort: 80, Protocol: "HTTP", InstanceProtocol: "FTP"},
+		"HTTPS without a certificate":  {LoadBalancerPort: 443, InstancePort: 80, Protocol: "HTTPS"},
+		"HTTP with a certificate":      {LoadBalancerPort: 80, InstancePort: 80, Protocol: "HTTP", SSLCertificateID: "7"},
+	}
+
+	t.Run("when the listener is valid", func(t *testing.T) {
+		for _, listener := range valid {
+			if err := listener.Validate(); err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		}
+	})
+
+	t.Run("when the listener is nil", func(t *testing.T) {
+		var listener *Listener
+
+		err := listener.Validate()
+
+		t.Run("the error is an InvalidListenerError", func(t *testing.T) {
+			if _, ok := err.(*InvalidListenerError); !ok {
+				t.Fatalf("Expected an *InvalidListenerError, got %T", err)
+			}
+		})
+
+		t.Run("the error can be described", func(t *testing.T) {
+			if err.Error() != "Invalid listener: the listener is missing" {
+				t.Errorf("Unexpected message '%s'", err)
+			}
+		})
+	})
+
+	for description, listener := range invalid {
+		t.Run("when the listener has "+description, func(t *testing.T) {
+			t.Run("the error is an InvalidListenerError", func(t *testing.T) {
+				if _, ok := listener.Validate().(*InvalidListenerError); !ok {
+					t.Errorf("Expected an *InvalidListenerError")
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package loadbalancers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/ess/maury/environments"
+)
+
+// ErrMissingSpec is returned when a load balancer is created without a spec
+var ErrMissingSpec = errors.New("A spec is required to create a load balancer")
+
+// ErrNoListeners is returned when a load balancer is created without any
+// listeners
+var ErrNoListeners = errors.New("At least one listener is required to create a load balancer")
+
+// ErrNoChanges is returned when an update is requested without any changes
+var ErrNoChanges = errors.New("Changes are required to update a load balancer")
+
+// Writer provides an interface for the functions that change load balancers
+// to talk to the API
+type Writer interface {
+	Reader
+	Post(string, url.Values, []byte) ([]byte, error)
+	Put(string, url.Values, []byte) ([]byte, error)
+	Delete(string, url.Values) ([]byte, error)
+}
+
+// Spec describes a load balancer to create
+type Spec struct {
+	HealthCheck *HealthCheck `json:"health_check,omitempty"`
+	Listeners   []*Listener  `json:"listeners,omitempty"`
+	Name        string       `json:"name,omitempty"`
+}
+
+// Changes models the aspects of a Load Balancer that we are allowed to change.
+// Listeners replaces all of the load balancer's listeners when it is given.
+type Changes struct {
+	HealthCheck *HealthCheck `json:"health_check,omitempty"`
+	Listeners   []*Listener  `json:"listeners,omitempty"`
+}
+
+// Create requests that a load balancer matching the given spec be created in
+// the given environment. If the spec is nil, the error is ErrMissingSpec. The
+// listeners are validated before anything is sent to the API, and if one is
+// invalid, the error is an *InvalidListenerError. Otherwise, the error is nil
+// and the created entity is returned.
+func Create(driver Writer, environment *environments.Entity, spec *Spec) (*Entity, error) {
+	if spec == nil {
+		return nil, ErrMissingSpec
+	}
+
+	if len(spec.Listeners) == 0 {
+		return nil, ErrNoListeners
+	}
+
+	if err := validateListeners(spec.Listeners); err != nil {
+		return nil, err
+	}
+
+	pathParts := []string{"environments", environment.ID, "load_balancers"}
+
+	return write(driver.Post, strings.Join(pathParts, "/"), spec)
+}
+
+// Update requests that a load balancer be updated on the API to match the
+// provided changes. If the changes are nil, the error is ErrNoChanges. The
+// listeners are validated as they are for Create. If there are issues along
+// the way, a non-nil error is returned. Otherwise, the error is nil and the
+// returned entity contains the requested changes.
+func Update(driver Writer, balancer *Entity, changes *Changes) (*Entity, error) {
+	if changes == nil {
+		return nil, ErrNoChanges
+	}
+
+	if err := validateListeners(changes.Listeners); err != nil {
+		return nil, err
+	}
+
+	return write(driver.Put, "load_balancers/"+balancer.ID, changes)
+}
+
+// UpdateCertificate requests that the listener on the given port of the given
+// load balancer use the SSL certificate with the given ID, leaving the other
+// listeners as they are. If the load balancer has no listener on the port, the
+// error is a *NoListenerError.
+func UpdateCertificate(driver Writer, balancer *Entity, port int, certificateID string) (*Entity, error) {
+	if balancer.Listener(port) == nil {
+		return nil, &NoListenerError{LoadBalancer: balancer, Port: port}
+	}
+
+	var listeners []*Listener
+
+	for _, listener := range balancer.Listeners {
+		updated := *listener
+
+		if updated.LoadBalancerPort == port {
+			updated.SSLCertificateID = certificateID
+		}
+
+		listeners = append(listeners, &updated)
+	}
+
+	return Update(driver, balancer, &Changes{Listeners: listeners})
+}
+
+// Delete requests that the given load balancer be removed. If there are issues
+// along the way, a non-nil error is returned.
+func Delete(driver Writer, balancer *Entity) error {
+	_, err := driver.Delete("load_balancers/"+balancer.ID, nil)
+
+	return err
+}
+
+func write(send func(string, url.Values, []byte) ([]byte, error), path string, body interface{}) (*Entity, error) {
+	wrapped := struct {
+		LoadBalancer interface{} `json:"load_balancer"`
+	}{
+		LoadBalancer: body,
+	}
+
+	data, err := json.Marshal(&wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := send(path, nil, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(response)
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
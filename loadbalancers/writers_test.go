@@ -0,0 +1,272 @@
+package loadbalancers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+)
+
+func sent(driver *maurytest.Driver) *Entity {
+	wrapper := struct {
+		LoadBalancer *Entity `json:"load_balancer"`
+	}{}
+
+	calls := driver.Calls()
+	if len(calls) > 0 {
+		json.Unmarshal(calls[0].Data, &wrapper)
+	}
+
+	return wrapper.LoadBalancer
+}
+
+func TestCreate(t *testing.T) {
+	environment := &environments.Entity{ID: "2"}
+
+	t.Run("when the spec is valid", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/load_balancers", nil, `{"load_balancer" : {"id" : "1", "name" : "web"}}`)
+
+		balancer, err := Create(driver, environment, &Spec{
+			Name:        "web",
+			Listeners:   []*Listener{{LoadBalancerPort: 443, InstancePort: 80, Protocol: "HTTPS", SSLCertificateID: "7"}},
+			HealthCheck: &HealthCheck{Target: "HTTP:80/health"},
+		})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the created load balancer", func(t *testing.T) {
+			if balancer == nil || balancer.ID != "1" {
+				t.Errorf("Expected load balancer 1")
+			}
+		})
+
+		t.Run("it sends the spec", func(t *testing.T) {
+			body := sent(driver)
+			if body == nil || body.Name != "web" || body.Listener(443) == nil || body.Listener(443).SSLCertificateID != "7" {
+				t.Errorf("Unexpected load balancer %+v", body)
+			}
+		})
+	})
+
+	t.Run("when a listener is invalid", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := Create(driver, environment, &Spec{
+			Name:      "web",
+			Listeners: []*Listener{{LoadBalancerPort: 443, InstancePort: 80, Protocol: "HTTPS"}},
+		})
+
+		t.Run("the error is an InvalidListenerError", func(t *testing.T) {
+			if _, ok := err.(*InvalidListenerError); !ok {
+				t.Errorf("Expected an *InvalidListenerError, got %T", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environments/2/load_balancers")
+		})
+	})
+
+	t.Run("when two listeners share a port", func(t *testing.T) {
+		_, err := Create(maurytest.NewDriver(), environment, &Spec{
+			Name: "web",
+			Listeners: []*Listener{
+				{LoadBalancerPort: 80, InstancePort: 80, Protocol: "HTTP"},
+				{LoadBalancerPort: 80, InstancePort: 8080, Protocol: "HTTP"},
+			},
+		})
+
+		t.Run("the error is an InvalidListenerError", func(t *testing.T) {
+			if _, ok := err.(*InvalidListenerError); !ok {
+				t.Errorf("Expected an *InvalidListenerError, got %T", err)
+			}
+		})
+	})
+
+	t.Run("when there are no listeners", func(t *testing.T) {
+		_, err := Create(maurytest.NewDriver(), environment, &Spec{Name: "web"})
+
+		t.Run("the error is ErrNoListeners", func(t *testing.T) {
+			if err != ErrNoListeners {
+				t.Errorf("Expected ErrNoListeners, got %v", err)
+			}
+		})
+	})
+
+	t.Run("when a listener is nil", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := Create(driver, environment, &Spec{
+			Name:      "web",
+			Listeners: []*Listener{{LoadBalancerPort: 80, InstancePort: 80, Protocol: "HTTP"}, nil},
+		})
+
+		t.Run("the error is an InvalidListenerError", func(t *testing.T) {
+			if _, ok := err.(*InvalidListenerError); !ok {
+				t.Errorf("Expected an *InvalidListenerError, got %T", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environments/2/load_balancers")
+		})
+	})
+
+	t.Run("when there is no spec", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := Create(driver, environment, nil)
+
+		t.Run("the error is ErrMissingSpec", func(t *testing.T) {
+			if err != ErrMissingSpec {
+				t.Errorf("Expected ErrMissingSpec, got %v", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environments/2/load_balancers")
+		})
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	balancer := &Entity{ID: "1"}
+
+	t.Run("when the changes are valid", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("PUT", "load_balancers/1", nil, `{"load_balancer" : {"id" : "1", "health_check" : {"target" : "TCP:80"}}}`)
+
+		updated, err := Update(driver, balancer, &Changes{HealthCheck: &HealthCheck{Target: "TCP:80"}})
+
+		t.Run("it returns the updated load balancer", func(t *testing.T) {
+			if err != nil || updated == nil || updated.HealthCheck.Target != "TCP:80" {
+				t.Errorf("Expected the updated load balancer, got error %v", err)
+			}
+		})
+	})
+
+	t.Run("when a listener is nil", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := Update(driver, balancer, &Changes{Listeners: []*Listener{nil}})
+
+		t.Run("the error is an InvalidListenerError", func(t *testing.T) {
+			if _, ok := err.(*InvalidListenerError); !ok {
+				t.Errorf("Expected an *InvalidListenerError, got %T", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "load_balancers/1")
+		})
+	})
+
+	t.Run("when there are no changes", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := Update(driver, balancer, nil)
+
+		t.Run("the error is ErrNoChanges", func(t *testing.T) {
+			if err != ErrNoChanges {
+				t.Errorf("Expected ErrNoChanges, got %v", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "load_balancers/1")
+		})
+	})
+}
+
+func TestUpdateCertificate(t *testing.T) {
+	balancer := &Entity{
+		ID: "1",
+		Listeners: []*Listener{
+			{LoadBalancerPort: 80, InstancePort: 80, Protocol: "HTTP"},
+			{LoadBalancerPort: 443, InstancePort: 80, Protocol: "HTTPS", SSLCertificateID: "7"},
+		},
+	}
+
+	t.Run("when the load balancer has a listener on the port", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("PUT", "load_balancers/1", nil, `{"load_balancer" : {"id" : "1"}}`)
+
+		_, err := UpdateCertificate(driver, balancer, 443, "8")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it sends every listener with the new certificate", func(t *testing.T) {
+			body := sent(driver)
+			if body == nil || len(body.Listeners) != 2 || body.Listener(443).SSLCertificateID != "8" {
+				t.Errorf("Unexpected load balancer %+v", body)
+			}
+		})
+
+		t.Run("it leaves the original entity alone", func(t *testing.T) {
+			if balancer.Listener(443).SSLCertificateID != "7" {
+				t.Errorf("Expected the original certificate to be kept")
+			}
+		})
+	})
+
+	t.Run("when the load balancer has no listener on the port", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := UpdateCertificate(driver, balancer, 8443, "8")
+
+		t.Run("the error is a NoListenerError", func(t *testing.T) {
+			if _, ok := err.(*NoListenerError); !ok {
+				t.Errorf("Expected a *NoListenerError, got %T", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "load_balancers/1")
+		})
+	})
+
+	t.Run("when the certificate is set on a plain listener", func(t *testing.T) {
+		_, err := UpdateCertificate(maurytest.NewDriver(), balancer, 80, "8")
+
+		t.Run("the error is an InvalidListenerError", func(t *testing.T) {
+			if _, ok := err.(*InvalidListenerError); !ok {
+				t.Errorf("Expected an *InvalidListenerError, got %T", err)
+			}
+		})
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("DELETE", "load_balancers/1", nil, errors.New("nope"))
+
+		t.Run("it has an error", func(t *testing.T) {
+			if Delete(driver, &Entity{ID: "1"}) == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when the API accepts the deletion", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("DELETE", "load_balancers/1", nil, ``)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err := Delete(driver, &Entity{ID: "1"}); err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	})
+}
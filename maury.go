@@ -2,14 +2,15 @@
 package maury
 
 import (
-  "github.com/ess/maury/client"
+	"github.com/ess/maury/client"
 )
 
 // NewClient returns a low-level HTTP driver configurd for the Engine Yard API
-// for the given base URL and token. If there are problems initializing the
-// client, then an error is returned.
-func NewClient(baseURL string, token string) (*client.Driver, error) {
-  return client.New(baseURL, token)
+// for the given base URL and token. Any options given are passed along to the
+// driver. If there are problems initializing the client, then an error is
+// returned.
+func NewClient(baseURL string, token string, options ...client.Option) (*client.Driver, error) {
+	return client.New(baseURL, token, options...)
 }
 
 // Copyright 2018 Dennis Walters
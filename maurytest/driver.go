@@ -0,0 +1,286 @@
+package maurytest
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ess/maury/client"
+)
+
+// Call is a record of a single request made against a Driver
+type Call struct {
+	Verb   string
+	Path   string
+	Params url.Values
+	Data   []byte
+}
+
+// Driver is a programmable test double that implements every verb of
+// client.Driver, so it satisfies the Reader, Updater and similar interfaces
+// in all of the resource packages. It records every call made against it.
+type Driver struct {
+	lock      sync.Mutex
+	baseURL   url.URL
+	responses map[string][]byte
+	failures  map[string]error
+	queued    map[string][]error
+	calls     []*Call
+}
+
+// NewDriver returns a Driver with no programmed responses
+func NewDriver() *Driver {
+	driver := &Driver{baseURL: url.URL{Scheme: "https", Host: "api.engineyard.com"}}
+	driver.Reset()
+
+	return driver
+}
+
+// Reset removes all programmed responses, failures and recorded calls
+func (driver *Driver) Reset() {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+
+	driver.responses = make(map[string][]byte)
+	driver.failures = make(map[string]error)
+	driver.queued = make(map[string][]error)
+	driver.calls = nil
+}
+
+// SetBaseURL changes the URL that the driver claims to resolve paths against,
+// which is https://api.engineyard.com by default
+func (driver *Driver) SetBaseURL(baseURL *url.URL) {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+
+	driver.baseURL = *baseURL
+}
+
+// BaseURL returns a copy of the URL that the driver claims to resolve paths
+// against
+func (driver *Driver) BaseURL() *url.URL {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+
+	copied := driver.baseURL
+
+	return &copied
+}
+
+// Respond programs the response for requests with the given verb, path and
+// params. If params is nil, the response is used for requests to the path
+// with any params that don't have a more specific response programmed.
+func (driver *Driver) Respond(verb string, path string, params url.Values, response string) {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+
+	driver.responses[key(verb, path, params)] = []byte(response)
+}
+
+// Fail causes every request with the given verb, path and params to return
+// the given error. As with Respond, nil params match any params.
+func (driver *Driver) Fail(verb string, path string, params url.Values, err error) {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+
+	driver.failures[key(verb, path, params)] = err
+}
+
+// FailNext causes the next request with the given verb and path to return the
+// given error. Calling it several times queues up errors for successive calls.
+func (driver *Driver) FailNext(verb string, path string, err error) {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+
+	name := verb + " " + path
+	driver.queued[name] = append(driver.queued[name], err)
+}
+
+// Calls returns all of the calls made against the driver, in order
+func (driver *Driver) Calls() []*Call {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+
+	calls := make([]*Call, len(driver.calls))
+	copy(calls, driver.calls)
+
+	return calls
+}
+
+// CallCount returns the number of calls made against the given path with any
+// verb and params
+func (driver *Driver) CallCount(path string) int {
+	count := 0
+
+	for _, call := range driver.Calls() {
+		if call.Path == path {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Called returns true if a call has been made against the given path with the
+// given params. Nil params match calls with no params.
+func (driver *Driver) Called(path string, params url.Values) bool {
+	for _, call := range driver.Calls() {
+		if call.Path == path && encode(call.Params) == encode(params) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertCalled marks the test as failed if no call has been made against the
+// given path with the given params
+func (driver *Driver) AssertCalled(t testing.TB, path string, params url.Values) {
+	if !driver.Called(path, params) {
+		t.Errorf("Expected a call to %s with params '%s'", path, encode(params))
+	}
+}
+
+// AssertNotCalled marks the test as failed if any call has been made against
+// the given path
+func (driver *Driver) AssertNotCalled(t testing.TB, path string) {
+	if count := driver.CallCount(path); count > 0 {
+		t.Errorf("Expected no calls to %s, got %d", path, count)
+	}
+}
+
+// Get performs a fake GET operation for the given path and params
+func (driver *Driver) Get(path string, params url.Values) ([]byte, error) {
+	return driver.handle("GET", path, params, nil)
+}
+
+// Post performs a fake POST operation for the given path, params and data
+func (driver *Driver) Post(path string, params url.Values, data []byte) ([]byte, error) {
+	return driver.handle("POST", path, params, data)
+}
+
+// Put performs a fake PUT operation for the given path, params and data
+func (driver *Driver) Put(path string, params url.Values, data []byte) ([]byte, error) {
+	return driver.handle("PUT", path, params, data)
+}
+
+// Patch performs a fake PATCH operation for the given path, params and data
+func (driver *Driver) Patch(path string, params url.Values, data []byte) ([]byte, error) {
+	return driver.handle("PATCH", path, params, data)
+}
+
+// Delete performs a fake DELETE operation for the given path and params
+func (driver *Driver) Delete(path string, params url.Values) ([]byte, error) {
+	return driver.handle("DELETE", path, params, nil)
+}
+
+func (driver *Driver) handle(verb string, path string, params url.Values, data []byte) ([]byte, error) {
+	driver.lock.Lock()
+	defer driver.lock.Unlock()
+
+	driver.calls = append(driver.calls, &Call{verb, path, copyParams(params), data})
+
+	name := verb + " " + path
+
+	if queued := driver.queued[name]; len(queued) > 0 {
+		driver.queued[name] = queued[1:]
+		return nil, queued[0]
+	}
+
+	specific := key(verb, path, params)
+	general := key(verb, path, nil)
+
+	if err, ok := driver.failures[specific]; ok {
+		return nil, err
+	}
+
+	if err, ok := driver.failures[general]; ok {
+		return nil, err
+	}
+
+	if response, ok := driver.responses[specific]; ok {
+		return response, nil
+	}
+
+	if response, ok := driver.responses[general]; ok {
+		return response, nil
+	}
+
+	return nil, errors.New("maurytest: no response programmed for " + specific)
+}
+
+// Page returns a copy of the given params with the page and per_page params
+// set the way that the finders in the resource packages send them, for use
+// with Respond and Fail when a collection spans several pages
+func Page(number int, params url.Values) url.Values {
+	page := copyParams(params)
+	if page == nil {
+		page = url.Values{}
+	}
+
+	page.Set("page", strconv.Itoa(number))
+	page.Set("per_page", strconv.Itoa(client.PerPage))
+
+	return page
+}
+
+// Collection returns a response body that wraps entities with the IDs first
+// through last under the given key, like the API does for collections
+func Collection(key string, first int, last int) string {
+	var entities []string
+
+	for id := first; id <= last; id++ {
+		entities = append(entities, fmt.Sprintf(`{"id" : "%d"}`, id))
+	}
+
+	return fmt.Sprintf(`{"%s" : [%s]}`, key, strings.Join(entities, ", "))
+}
+
+func key(verb string, path string, params url.Values) string {
+	if params == nil {
+		return verb + " " + path
+	}
+
+	return verb + " " + path + "?" + params.Encode()
+}
+
+func encode(params url.Values) string {
+	if params == nil {
+		return ""
+	}
+
+	return params.Encode()
+}
+
+// copyParams protects recorded calls from callers that reuse their params
+func copyParams(params url.Values) url.Values {
+	if params == nil {
+		return nil
+	}
+
+	copied := url.Values{}
+
+	for name, values := range params {
+		copied[name] = append([]string(nil), values...)
+	}
+
+	return copied
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,139 @@
+package maurytest
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/users"
+)
+
+type recorder struct {
+	testing.TB
+	failures int
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures++
+}
+
+func TestDriver(t *testing.T) {
+	t.Run("when a response is programmed", func(t *testing.T) {
+		driver := NewDriver()
+		driver.Respond("GET", "accounts/1", nil, `{"account" : {"id" : "1"}}`)
+
+		account, err := accounts.Find(driver, "1")
+
+		t.Run("it returns the response", func(t *testing.T) {
+			if err != nil || account.ID != "1" {
+				t.Errorf("Expected account 1, got %v (%v)", account, err)
+			}
+		})
+
+		t.Run("it records the call", func(t *testing.T) {
+			if driver.CallCount("accounts/1") != 1 {
+				t.Errorf("Expected 1 call, got %d", driver.CallCount("accounts/1"))
+			}
+
+			driver.AssertCalled(t, "accounts/1", nil)
+		})
+	})
+
+	t.Run("when a response is programmed without params", func(t *testing.T) {
+		driver := NewDriver()
+		driver.Respond("GET", "users", nil, `{"users" : [{"id" : "1"}, {"id" : "2"}]}`)
+
+		all, err := users.All(driver, nil)
+
+		t.Run("it matches requests with any params", func(t *testing.T) {
+			if err != nil || len(all) != 2 {
+				t.Errorf("Expected 2 users, got %d", len(all))
+			}
+		})
+
+		t.Run("it records the params that were sent", func(t *testing.T) {
+			params := url.Values{}
+			params.Set("page", "1")
+			params.Set("per_page", "100")
+
+			driver.AssertCalled(t, "users", params)
+		})
+	})
+
+	t.Run("when no response is programmed", func(t *testing.T) {
+		driver := NewDriver()
+
+		_, err := accounts.Find(driver, "1")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+
+	t.Run("when an error is injected for the next call", func(t *testing.T) {
+		driver := NewDriver()
+		driver.Respond("PUT", "accounts/1", nil, `{"account" : {"id" : "1", "name" : "Sausages"}}`)
+
+		failure := errors.New("kaboom")
+		driver.FailNext("PUT", "accounts/1", failure)
+
+		changes := &accounts.Changes{Name: "Sausages"}
+		_, first := accounts.Update(driver, &accounts.Entity{ID: "1"}, changes)
+		updated, second := accounts.Update(driver, &accounts.Entity{ID: "1"}, changes)
+
+		t.Run("the first call fails", func(t *testing.T) {
+			if first != failure {
+				t.Errorf("Expected the injected error, got %v", first)
+			}
+		})
+
+		t.Run("the second call succeeds", func(t *testing.T) {
+			if second != nil || updated.Name != "Sausages" {
+				t.Errorf("Expected a successful update, got %v", second)
+			}
+		})
+
+		t.Run("it records the data that was sent", func(t *testing.T) {
+			calls := driver.Calls()
+
+			if len(calls) != 2 || string(calls[1].Data) != `{"account":{"name":"Sausages"}}` {
+				t.Errorf("Expected the update payload to be recorded")
+			}
+		})
+	})
+
+	t.Run("when an error is injected for every call", func(t *testing.T) {
+		driver := NewDriver()
+		driver.Respond("DELETE", "sausages", nil, `{}`)
+		driver.Fail("DELETE", "sausages", nil, errors.New("nope"))
+
+		_, first := driver.Delete("sausages", nil)
+		_, second := driver.Delete("sausages", nil)
+
+		t.Run("every call fails", func(t *testing.T) {
+			if first == nil || second == nil {
+				t.Errorf("Expected both calls to fail")
+			}
+		})
+	})
+
+	t.Run("when asserting on a call that wasn't made", func(t *testing.T) {
+		driver := NewDriver()
+		driver.Respond("POST", "sausages", nil, `{}`)
+		driver.Post("sausages", nil, nil)
+
+		r := &recorder{TB: t}
+
+		driver.AssertCalled(r, "bratwurst", nil)
+		driver.AssertNotCalled(r, "sausages")
+
+		t.Run("the assertions fail", func(t *testing.T) {
+			if r.failures != 2 {
+				t.Errorf("Expected 2 failures, got %d", r.failures)
+			}
+		})
+	})
+}
@@ -0,0 +1,404 @@
+// Package maurytest provides utilities for testing code that is built on top
+// of maury
+package maurytest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/timestamp"
+	"github.com/ess/maury/users"
+)
+
+// Server is an in-memory fake of the Engine Yard API. It is backed by an
+// httptest.Server, so a real client.Driver can be pointed at it.
+type Server struct {
+	// URL is the base URL of the fake API
+	URL string
+
+	// Token is the API token that requests must present via X-EY-TOKEN
+	Token string
+
+	raw         *httptest.Server
+	lock        sync.Mutex
+	accounts    []*accounts.Entity
+	users       []*users.Entity
+	memberships map[string][]string
+	current     string
+	failures    map[string]failure
+}
+
+type failure struct {
+	status int
+	body   string
+}
+
+// NewServer starts a fake API that accepts the given token. The caller should
+// Close the server when finished with it.
+func NewServer(token string) *Server {
+	server := &Server{
+		Token:       token,
+		memberships: make(map[string][]string),
+		failures:    make(map[string]failure),
+	}
+
+	server.raw = httptest.NewServer(http.HandlerFunc(server.handle))
+	server.URL = server.raw.URL
+
+	return server
+}
+
+// Close shuts down the fake API
+func (server *Server) Close() {
+	server.raw.Close()
+}
+
+// Driver returns a client.Driver that is configured to talk to the fake API
+// with the server's token
+func (server *Server) Driver() (*client.Driver, error) {
+	return client.New(server.URL, server.Token)
+}
+
+// AddAccount seeds the fake API with a copy of the given account
+func (server *Server) AddAccount(account *accounts.Entity) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	copied := *account
+	server.accounts = append(server.accounts, &copied)
+}
+
+// AddUser seeds the fake API with a copy of the given user, making the user a
+// member of the given accounts
+func (server *Server) AddUser(user *users.Entity, memberOf ...*accounts.Entity) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	copied := *user
+	server.users = append(server.users, &copied)
+
+	for _, account := range memberOf {
+		server.memberships[user.ID] = append(server.memberships[user.ID], account.ID)
+	}
+}
+
+// SetCurrentUser sets the user that the fake API treats as the owner of the
+// server's token
+func (server *Server) SetCurrentUser(user *users.Entity) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	server.current = user.ID
+}
+
+// Account returns the fake API's current copy of the account with the given
+// ID, or nil if there is no such account
+func (server *Server) Account(id string) *accounts.Entity {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	account := server.findAccount(id)
+	if account == nil {
+		return nil
+	}
+
+	copied := *account
+	return &copied
+}
+
+// User returns the fake API's current copy of the user with the given ID, or
+// nil if there is no such user
+func (server *Server) User(id string) *users.Entity {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	user := server.findUser(id)
+	if user == nil {
+		return nil
+	}
+
+	copied := *user
+	return &copied
+}
+
+// Fail causes all requests with the given verb and path to receive the given
+// status and body until ClearFailures is called. The path should not have a
+// leading slash, just like the paths passed to client.Driver.
+func (server *Server) Fail(verb string, path string, status int, body string) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	server.failures[verb+" "+path] = failure{status, body}
+}
+
+// ClearFailures removes all failures that have been injected via Fail
+func (server *Server) ClearFailures() {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	server.failures = make(map[string]failure)
+}
+
+func (server *Server) handle(w http.ResponseWriter, r *http.Request) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Header.Get("X-EY-TOKEN") != server.Token {
+		writeErrors(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+
+	if f, ok := server.failures[r.Method+" "+path]; ok {
+		w.WriteHeader(f.status)
+		w.Write([]byte(f.body))
+		return
+	}
+
+	parts := strings.Split(path, "/")
+
+	switch {
+	case r.Method == "GET" && path == "accounts":
+		server.writePage(w, r, "accounts", server.accounts)
+	case r.Method == "GET" && len(parts) == 2 && parts[0] == "accounts":
+		server.showAccount(w, parts[1])
+	case r.Method == "PUT" && len(parts) == 2 && parts[0] == "accounts":
+		server.updateAccount(w, r, parts[1])
+	case r.Method == "POST" && len(parts) == 3 && parts[0] == "accounts":
+		server.changeAccountStatus(w, parts[1], parts[2])
+	case r.Method == "GET" && path == "users":
+		server.listUsers(w, r)
+	case r.Method == "GET" && path == "users/current":
+		server.showUser(w, server.current)
+	case r.Method == "GET" && len(parts) == 2 && parts[0] == "users":
+		server.showUser(w, parts[1])
+	case r.Method == "GET" && len(parts) == 3 && parts[0] == "users" && parts[2] == "accounts":
+		server.listUserAccounts(w, r, parts[1])
+	default:
+		writeErrors(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (server *Server) showAccount(w http.ResponseWriter, id string) {
+	account := server.findAccount(id)
+	if account == nil {
+		writeErrors(w, http.StatusNotFound, "Account not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"account": account})
+}
+
+func (server *Server) updateAccount(w http.ResponseWriter, r *http.Request, id string) {
+	account := server.findAccount(id)
+	if account == nil {
+		writeErrors(w, http.StatusNotFound, "Account not found")
+		return
+	}
+
+	wrapper := struct {
+		Account *accounts.Changes `json:"account"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&wrapper); err != nil || wrapper.Account == nil {
+		writeErrors(w, http.StatusUnprocessableEntity, "Invalid account")
+		return
+	}
+
+	changes := wrapper.Account
+
+	if len(changes.SupportPlan) > 0 && !changes.SupportPlan.Known() {
+		writeErrors(w, http.StatusUnprocessableEntity, "Support plan is invalid")
+		return
+	}
+
+	if len(changes.Name) > 0 {
+		account.Name = changes.Name
+	}
+
+	if len(changes.EmergencyContact) > 0 {
+		account.EmergencyContact = changes.EmergencyContact
+	}
+
+	if len(changes.SupportPlan) > 0 {
+		account.SupportPlan = changes.SupportPlan
+	}
+
+	account.UpdatedAt = timestamp.New(time.Now().UTC())
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"account": account})
+}
+
+func (server *Server) changeAccountStatus(w http.ResponseWriter, id string, action string) {
+	account := server.findAccount(id)
+	if account == nil {
+		writeErrors(w, http.StatusNotFound, "Account not found")
+		return
+	}
+
+	switch action {
+	case "cancel":
+		account.CancelledAt = timestamp.New(time.Now().UTC())
+	case "reactivate":
+		account.CancelledAt = timestamp.Time{}
+	default:
+		writeErrors(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"account": account})
+}
+
+func (server *Server) showUser(w http.ResponseWriter, id string) {
+	user := server.findUser(id)
+	if user == nil {
+		writeErrors(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user": user})
+}
+
+func (server *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	email := r.URL.Query().Get("email")
+	if len(email) == 0 {
+		server.writePage(w, r, "users", server.users)
+		return
+	}
+
+	matches := []*users.Entity{}
+
+	for _, user := range server.users {
+		if strings.EqualFold(user.Email, email) {
+			matches = append(matches, user)
+		}
+	}
+
+	server.writePage(w, r, "users", matches)
+}
+
+func (server *Server) listUserAccounts(w http.ResponseWriter, r *http.Request, id string) {
+	if server.findUser(id) == nil {
+		writeErrors(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	matches := []*accounts.Entity{}
+
+	for _, accountID := range server.memberships[id] {
+		if account := server.findAccount(accountID); account != nil {
+			matches = append(matches, account)
+		}
+	}
+
+	server.writePage(w, r, "accounts", matches)
+}
+
+func (server *Server) findAccount(id string) *accounts.Entity {
+	for _, account := range server.accounts {
+		if account.ID == id {
+			return account
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) findUser(id string) *users.Entity {
+	for _, user := range server.users {
+		if user.ID == id {
+			return user
+		}
+	}
+
+	return nil
+}
+
+// writePage writes the page of the given collection requested via the page
+// and per_page params, wrapped in the given envelope
+func (server *Server) writePage(w http.ResponseWriter, r *http.Request, envelope string, collection interface{}) {
+	items := toSlice(collection)
+
+	page := intParam(r, "page", 1)
+	perPage := intParam(r, "per_page", 25)
+
+	start := (page - 1) * perPage
+	if start > len(items) {
+		start = len(items)
+	}
+
+	finish := start + perPage
+	if finish > len(items) {
+		finish = len(items)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{envelope: items[start:finish]})
+}
+
+func toSlice(collection interface{}) []interface{} {
+	items := []interface{}{}
+
+	switch typed := collection.(type) {
+	case []*accounts.Entity:
+		for _, item := range typed {
+			items = append(items, item)
+		}
+	case []*users.Entity:
+		for _, item := range typed {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func intParam(r *http.Request, name string, fallback int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || value < 1 {
+		return fallback
+	}
+
+	return value
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		writeErrors(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+func writeErrors(w http.ResponseWriter, status int, messages ...string) {
+	data, _ := json.Marshal(map[string][]string{"errors": messages})
+
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,150 @@
+package maurytest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/users"
+)
+
+func TestServer(t *testing.T) {
+	server := NewServer("sekrit")
+	defer server.Close()
+
+	for x := 1; x <= 120; x++ {
+		server.AddAccount(&accounts.Entity{ID: fmt.Sprintf("%d", x), Name: fmt.Sprintf("Account %d", x)})
+	}
+
+	bob := &users.Entity{ID: "bob", Email: "bob@example.com"}
+	server.AddUser(bob, &accounts.Entity{ID: "1"}, &accounts.Entity{ID: "2"})
+	server.SetCurrentUser(bob)
+
+	driver, _ := server.Driver()
+
+	t.Run("when listing accounts", func(t *testing.T) {
+		all, err := accounts.All(driver, nil)
+
+		t.Run("it returns every page", func(t *testing.T) {
+			if err != nil || len(all) != 120 {
+				t.Errorf("Expected 120 accounts, got %d", len(all))
+			}
+		})
+	})
+
+	t.Run("when listing the accounts for a user", func(t *testing.T) {
+		all, err := accounts.ForUser(driver, bob, nil)
+
+		t.Run("it returns only the user's accounts", func(t *testing.T) {
+			if err != nil || len(all) != 2 {
+				t.Errorf("Expected 2 accounts, got %d", len(all))
+			}
+		})
+	})
+
+	t.Run("when finding an account", func(t *testing.T) {
+		t.Run("and the account exists", func(t *testing.T) {
+			account, err := accounts.Find(driver, "42")
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if account.Name != "Account 42" {
+				t.Errorf("Expected Account 42, got '%s'", account.Name)
+			}
+		})
+
+		t.Run("and the account does not exist", func(t *testing.T) {
+			_, err := accounts.Find(driver, "8675309")
+
+			apiErr, ok := err.(*client.APIError)
+			if !ok || apiErr.StatusCode != 404 {
+				t.Errorf("Expected a 404, got %s", err)
+			}
+		})
+	})
+
+	t.Run("when updating an account", func(t *testing.T) {
+		account := &accounts.Entity{ID: "7"}
+
+		updated, err := accounts.Update(driver, account, &accounts.Changes{Name: "Sausages"})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("the response has the change", func(t *testing.T) {
+			if updated.Name != "Sausages" {
+				t.Errorf("Expected the name to be updated")
+			}
+		})
+
+		t.Run("the change is persisted", func(t *testing.T) {
+			if server.Account("7").Name != "Sausages" {
+				t.Errorf("Expected the stored account to be updated")
+			}
+		})
+
+		t.Run("the stored account can't be changed through the copy", func(t *testing.T) {
+			server.Account("7").Name = "Tofu"
+
+			if server.Account("7").Name != "Sausages" {
+				t.Errorf("Expected the stored account to be unchanged")
+			}
+		})
+	})
+
+	t.Run("when looking up the current user", func(t *testing.T) {
+		user, err := users.Current(driver)
+
+		if err != nil || user.ID != "bob" {
+			t.Errorf("Expected bob, got %v (%v)", user, err)
+		}
+	})
+
+	t.Run("when looking up a user by email", func(t *testing.T) {
+		user, err := users.FindByEmail(driver, "BOB@example.com")
+
+		if err != nil || user.ID != "bob" {
+			t.Errorf("Expected bob, got %v (%v)", user, err)
+		}
+	})
+
+	t.Run("when the token is wrong", func(t *testing.T) {
+		impostor, _ := client.New(server.URL, "guess")
+
+		_, err := users.Current(impostor)
+
+		apiErr, ok := err.(*client.APIError)
+		if !ok || apiErr.StatusCode != 401 {
+			t.Errorf("Expected a 401, got %s", err)
+		}
+	})
+
+	t.Run("when a failure is injected", func(t *testing.T) {
+		server.Fail("GET", "accounts/1", 503, "Down for maintenance")
+
+		_, err := accounts.Find(driver, "1")
+
+		t.Run("the request fails", func(t *testing.T) {
+			apiErr, ok := err.(*client.APIError)
+			if !ok || apiErr.StatusCode != 503 {
+				t.Errorf("Expected a 503, got %s", err)
+			}
+		})
+
+		server.ClearFailures()
+
+		_, err = accounts.Find(driver, "1")
+
+		t.Run("the failure can be cleared", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+	})
+}
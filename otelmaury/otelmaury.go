@@ -0,0 +1,205 @@
+// Package otelmaury provides OpenTelemetry instrumentation for client.Driver.
+// It is kept apart from the client package so that programs which don't use
+// OpenTelemetry don't have to depend on it.
+//
+// To trace requests as part of the caller's work, pass the caller's context
+// along via client.Driver.WithContext:
+//
+//	driver, _ := client.New(url, token, client.WithMiddleware(otelmaury.Middleware()))
+//	account, err := accounts.Find(driver.WithContext(ctx), id)
+package otelmaury
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ess/maury/client"
+)
+
+// InstrumentationName is the name reported for the tracer and meter that
+// instrument the driver
+const InstrumentationName = "github.com/ess/maury/otelmaury"
+
+// Attribute keys recorded on spans and metrics, in addition to the standard
+// HTTP attributes
+const (
+	PathTemplateKey = attribute.Key("maury.path_template")
+	ResourceKey     = attribute.Key("maury.resource")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option configures the instrumentation
+type Option func(*config)
+
+// WithTracerProvider configures the instrumentation to create spans with the
+// given provider rather than the global one
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider configures the instrumentation to record metrics with the
+// given provider rather than the global one
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagator configures the instrumentation to inject trace context into
+// requests with the given propagator rather than the global one
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+// Middleware returns a client.Middleware that creates a client span for every
+// request, propagates the trace context to the upstream API, and records the
+// number of requests, their latency and the number of errors.
+func Middleware(options ...Option) client.Middleware {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	tracer := c.tracerProvider.Tracer(InstrumentationName)
+	meter := c.meterProvider.Meter(InstrumentationName)
+
+	requests, err := meter.Int64Counter(
+		"maury.client.requests",
+		metric.WithDescription("The number of requests made to the Engine Yard API"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	failures, err := meter.Int64Counter(
+		"maury.client.errors",
+		metric.WithDescription("The number of requests to the Engine Yard API that failed"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	latency, err := meter.Float64Histogram(
+		"maury.client.duration",
+		metric.WithDescription("The duration of requests to the Engine Yard API"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next client.RoundTrip) client.RoundTrip {
+		return func(request *http.Request) (*http.Response, error) {
+			template := PathTemplate(request.URL.Path)
+
+			attrs := []attribute.KeyValue{
+				attribute.String("http.request.method", request.Method),
+				PathTemplateKey.String(template),
+				ResourceKey.String(resource(template)),
+			}
+
+			ctx, span := tracer.Start(
+				request.Context(),
+				"maury "+request.Method+" "+template,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(attribute.String("url.full", request.URL.String())),
+			)
+			defer span.End()
+
+			request = request.WithContext(ctx)
+			c.propagator.Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+			started := time.Now()
+			response, err := next(request)
+			elapsed := time.Since(started).Seconds()
+
+			failed := err != nil
+
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				status := attribute.Int("http.response.status_code", response.StatusCode)
+				attrs = append(attrs, status)
+				span.SetAttributes(status)
+
+				if response.StatusCode >= 400 {
+					failed = true
+					span.SetStatus(codes.Error, strconv.Itoa(response.StatusCode)+" "+http.StatusText(response.StatusCode))
+				}
+			}
+
+			set := metric.WithAttributes(attrs...)
+
+			requests.Add(ctx, 1, set)
+			latency.Record(ctx, elapsed, set)
+
+			if failed {
+				failures.Add(ctx, 1, set)
+			}
+
+			return response, err
+		}
+	}
+}
+
+var identifier = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F-]{8,})$`)
+
+// PathTemplate converts a request path into a template suitable for naming
+// spans and grouping metrics, by replacing the segments that look like record
+// identifiers with {id}
+func PathTemplate(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for index, segment := range segments {
+		if identifier.MatchString(segment) && strings.ContainsAny(segment, "0123456789") {
+			segments[index] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// resource returns the top-level resource addressed by a path template
+func resource(template string) string {
+	return strings.SplitN(template, "/", 2)[0]
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
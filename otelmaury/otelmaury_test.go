@@ -0,0 +1,164 @@
+package otelmaury
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/client"
+)
+
+func TestMiddleware(t *testing.T) {
+	var traceparent string
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+
+		if r.URL.Path == "/accounts/8675309" {
+			w.WriteHeader(404)
+			return
+		}
+
+		w.Write([]byte(`{"account" : {"id" : "12345"}}`))
+	}))
+	defer api.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	driver, _ := client.New(
+		api.URL,
+		"sekrit",
+		client.WithMiddleware(
+			Middleware(
+				WithTracerProvider(tracerProvider),
+				WithMeterProvider(meterProvider),
+				WithPropagator(propagation.TraceContext{}),
+			),
+		),
+	)
+
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "billing report")
+
+	accounts.Find(driver.WithContext(ctx), "12345")
+	accounts.Find(driver.WithContext(ctx), "8675309")
+
+	parent.End()
+
+	spans := exporter.GetSpans()
+
+	t.Run("it creates a span per request", func(t *testing.T) {
+		if len(spans) != 3 {
+			t.Fatalf("Expected 3 spans, got %d", len(spans))
+		}
+
+		if spans[0].Name != "maury GET accounts/{id}" {
+			t.Errorf("Unexpected span name '%s'", spans[0].Name)
+		}
+	})
+
+	t.Run("the spans are children of the caller's span", func(t *testing.T) {
+		if spans[0].Parent.SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Expected the request span to have the caller's span as its parent")
+		}
+	})
+
+	t.Run("the spans have the request attributes", func(t *testing.T) {
+		expected := map[attribute.Key]string{
+			"http.request.method":       "GET",
+			"http.response.status_code": "200",
+			PathTemplateKey:             "accounts/{id}",
+			ResourceKey:                 "accounts",
+		}
+
+		for key, value := range expected {
+			found := false
+
+			for _, attr := range spans[0].Attributes {
+				if attr.Key == key && attr.Value.Emit() == value {
+					found = true
+				}
+			}
+
+			if !found {
+				t.Errorf("Expected %s=%s", key, value)
+			}
+		}
+	})
+
+	t.Run("failed requests are marked as errors", func(t *testing.T) {
+		if spans[1].Status.Code != codes.Error {
+			t.Errorf("Expected an error status, got %v", spans[1].Status)
+		}
+	})
+
+	t.Run("the trace context is propagated upstream", func(t *testing.T) {
+		if len(traceparent) == 0 {
+			t.Errorf("Expected a traceparent header")
+		}
+	})
+
+	t.Run("it records metrics", func(t *testing.T) {
+		var data metricdata.ResourceMetrics
+		reader.Collect(context.Background(), &data)
+
+		totals := make(map[string]int64)
+
+		for _, scope := range data.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				switch d := m.Data.(type) {
+				case metricdata.Sum[int64]:
+					for _, point := range d.DataPoints {
+						totals[m.Name] += point.Value
+					}
+				case metricdata.Histogram[float64]:
+					for _, point := range d.DataPoints {
+						totals[m.Name] += int64(point.Count)
+					}
+				}
+			}
+		}
+
+		if totals["maury.client.requests"] != 2 {
+			t.Errorf("Expected 2 requests, got %d", totals["maury.client.requests"])
+		}
+
+		if totals["maury.client.duration"] != 2 {
+			t.Errorf("Expected 2 latency measurements, got %d", totals["maury.client.duration"])
+		}
+
+		if totals["maury.client.errors"] != 1 {
+			t.Errorf("Expected 1 error, got %d", totals["maury.client.errors"])
+		}
+	})
+}
+
+func TestPathTemplate(t *testing.T) {
+	cases := map[string]string{
+		"/accounts":                         "accounts",
+		"/accounts/12345":                   "accounts/{id}",
+		"/users/5f3e1c2a-aaaa/accounts":     "users/{id}/accounts",
+		"/users/current":                    "users/current",
+		"/accounts/1c3ef22b9a0d/cancel":     "accounts/{id}/cancel",
+		"/environments/staging/deployments": "environments/staging/deployments",
+	}
+
+	for path, expected := range cases {
+		if actual := PathTemplate(path); actual != expected {
+			t.Errorf("Expected '%s' for '%s', got '%s'", expected, path, actual)
+		}
+	}
+}
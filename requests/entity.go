@@ -0,0 +1,58 @@
+// Package requests provides the data structures and functions for modeling
+// the Requests endpoint on the Engine Yard API. Requests track the progress
+// of long-running operations like deploys and environment boots.
+package requests
+
+import (
+	"strings"
+
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to an upstream Request
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Request Details
+	Message    string `json:"message,omitempty"`
+	Successful bool   `json:"successful,omitempty"`
+	Type       string `json:"type,omitempty"`
+
+	// Relation URLs
+	Account  string `json:"account,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	User     string `json:"user,omitempty"`
+
+	// Timestamps
+	CreatedAt  timestamp.Time `json:"created_at,omitempty"`
+	FinishedAt timestamp.Time `json:"finished_at,omitempty"`
+	StartedAt  timestamp.Time `json:"started_at,omitempty"`
+	UpdatedAt  timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Finished returns true if the request has finished, successfully or not
+func (request *Entity) Finished() bool {
+	return !request.FinishedAt.IsZero()
+}
+
+// ResourceID returns the ID of the resource that the request acted upon, as
+// taken from the last segment of its resource URL
+func (request *Entity) ResourceID() string {
+	parts := strings.Split(strings.TrimRight(request.Resource, "/"), "/")
+
+	return parts[len(parts)-1]
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,62 @@
+package requests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+)
+
+// ErrMissingRequest is returned when an API response that should describe a
+// request does not
+var ErrMissingRequest = errors.New("The API response did not include a request")
+
+// Reader provides an interface for the finder functions to talk to the API
+type Reader interface {
+	Get(string, url.Values) ([]byte, error)
+}
+
+// Find queries the API for a single request entity by request ID. If there
+// are problems along the way, a non-nil error is returned. Otherwise, the
+// error is nil and the entity is populated.
+func Find(driver Reader, id string) (*Entity, error) {
+	response, err := driver.Get("requests/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(response)
+}
+
+// Parse extracts a request entity from an API response that wraps it, such as
+// the responses to the operations that start long-running requests. If the
+// response does not include a request, the error is ErrMissingRequest.
+func Parse(response []byte) (*Entity, error) {
+	wrapper := struct {
+		Request *Entity `json:"request,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	if wrapper.Request == nil {
+		return nil, ErrMissingRequest
+	}
+
+	return wrapper.Request, nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
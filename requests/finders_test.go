@@ -0,0 +1,44 @@
+package requests
+
+import (
+	"testing"
+
+	"github.com/ess/maury/maurytest"
+)
+
+func TestFind(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("GET", "requests/1", nil, succeeded)
+
+	result, err := Find(driver, "1")
+
+	t.Run("it has no error", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it is populated", func(t *testing.T) {
+		if result == nil || result.Type != "deploy" || !result.Successful {
+			t.Errorf("Expected a successful deploy request")
+		}
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("when the response does not include a request", func(t *testing.T) {
+		result, err := Parse([]byte(`{"deployment" : {"id" : "1"}}`))
+
+		t.Run("the error is ErrMissingRequest", func(t *testing.T) {
+			if err != ErrMissingRequest {
+				t.Errorf("Expected ErrMissingRequest, got %v", err)
+			}
+		})
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+	})
+}
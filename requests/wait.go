@@ -0,0 +1,88 @@
+package requests
+
+import (
+	"context"
+	"time"
+
+	"github.com/ess/maury/client"
+)
+
+// FailedError is returned when a request finishes unsuccessfully
+type FailedError struct {
+	Request *Entity
+}
+
+func (err *FailedError) Error() string {
+	return "Request " + err.Request.ID + " failed: " + err.Request.Message
+}
+
+// Progress is called with the latest state of a request each time that it is
+// polled
+type Progress func(*Entity)
+
+// Wait polls the API at the given interval until the given request has
+// finished, calling progress (if it is not nil) with the state of the request
+// after each poll. If the driver is a *client.Driver, each poll is made within
+// the given context. If the context is done before the request finishes, the
+// error is the context's error. If there are other problems along the way, a
+// non-nil error is returned. If the request is nil or the API stops
+// describing it, the error is ErrMissingRequest. If the request finishes
+// unsuccessfully, the error is a *FailedError. Otherwise, the error is nil
+// and the finished entity is returned.
+func Wait(ctx context.Context, driver Reader, request *Entity, interval time.Duration, progress Progress) (*Entity, error) {
+	if request == nil {
+		return nil, ErrMissingRequest
+	}
+
+	if bound, ok := driver.(*client.Driver); ok {
+		driver = bound.WithContext(ctx)
+	}
+
+	current := request
+
+	for !current.Finished() {
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+
+		latest, err := Find(driver, request.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if latest == nil {
+			return nil, ErrMissingRequest
+		}
+
+		current = latest
+
+		if progress != nil {
+			progress(current)
+		}
+	}
+
+	if !current.Successful {
+		return current, &FailedError{Request: current}
+	}
+
+	return current, nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,224 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/maurytest"
+)
+
+const pending = `{"request" : {"id" : "1", "type" : "deploy"}}`
+const succeeded = `{"request" : {"id" : "1", "type" : "deploy", "successful" : true, "finished_at" : "2018-01-01T00:00:00Z"}}`
+const failed = `{"request" : {"id" : "1", "type" : "deploy", "message" : "boom", "finished_at" : "2018-01-01T00:00:00Z"}}`
+
+func TestWait(t *testing.T) {
+	t.Run("when the request is already finished", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		request, _ := Parse([]byte(succeeded))
+
+		result, err := Wait(context.Background(), driver, request, 0, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the request", func(t *testing.T) {
+			if result != request {
+				t.Errorf("Expected the original request")
+			}
+		})
+
+		t.Run("it does not poll the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "requests/1")
+		})
+	})
+
+	t.Run("when the request finishes successfully", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "requests/1", nil, pending)
+		request, _ := Parse([]byte(pending))
+
+		polls := 0
+		result, err := Wait(context.Background(), driver, request, 0, func(latest *Entity) {
+			polls++
+
+			if polls == 2 {
+				driver.Respond("GET", "requests/1", nil, succeeded)
+			}
+		})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it reports progress for every poll", func(t *testing.T) {
+			if polls != 3 {
+				t.Errorf("Expected 3 polls, got %d", polls)
+			}
+		})
+
+		t.Run("it returns the finished request", func(t *testing.T) {
+			if result == nil || !result.Finished() || !result.Successful {
+				t.Errorf("Expected a successful, finished request")
+			}
+		})
+	})
+
+	t.Run("when the request fails", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "requests/1", nil, failed)
+		request, _ := Parse([]byte(pending))
+
+		result, err := Wait(context.Background(), driver, request, 0, nil)
+
+		t.Run("the error is a FailedError", func(t *testing.T) {
+			if _, ok := err.(*FailedError); !ok {
+				t.Errorf("Expected a *FailedError, got %T", err)
+			}
+		})
+
+		t.Run("it returns the failed request", func(t *testing.T) {
+			if result == nil || result.Message != "boom" {
+				t.Errorf("Expected the failed request")
+			}
+		})
+	})
+
+	t.Run("when the API cannot be reached", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "requests/1", nil, errors.New("nope"))
+		request, _ := Parse([]byte(pending))
+
+		result, err := Wait(context.Background(), driver, request, 0, nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || err.Error() != "nope" {
+				t.Errorf("Expected the driver error, got %v", err)
+			}
+		})
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+	})
+
+	t.Run("when there is no request", func(t *testing.T) {
+		_, err := Wait(context.Background(), maurytest.NewDriver(), nil, 0, nil)
+
+		t.Run("the error is ErrMissingRequest", func(t *testing.T) {
+			if err != ErrMissingRequest {
+				t.Errorf("Expected ErrMissingRequest, got %v", err)
+			}
+		})
+	})
+
+	t.Run("when the API stops describing the request", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "requests/1", nil, `{}`)
+		request, _ := Parse([]byte(pending))
+
+		result, err := Wait(context.Background(), driver, request, 0, nil)
+
+		t.Run("the error is ErrMissingRequest", func(t *testing.T) {
+			if err != ErrMissingRequest {
+				t.Errorf("Expected ErrMissingRequest, got %v", err)
+			}
+		})
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+	})
+}
+
+func TestEntity_ResourceID(t *testing.T) {
+	request := &Entity{Resource: "https://api.engineyard.com/deployments/1234"}
+
+	t.Run("it is the last segment of the resource URL", func(t *testing.T) {
+		if request.ResourceID() != "1234" {
+			t.Errorf("Expected 1234, got %s", request.ResourceID())
+		}
+	})
+	t.Run("when the request never finishes", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "requests/1", nil, pending)
+		request, _ := Parse([]byte(pending))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		result, err := Wait(ctx, driver, request, time.Millisecond, nil)
+
+		t.Run("the error is the context's error", func(t *testing.T) {
+			if err != context.DeadlineExceeded {
+				t.Errorf("Expected the deadline to be exceeded, got %v", err)
+			}
+		})
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+
+		t.Run("it polled until the deadline", func(t *testing.T) {
+			if driver.CallCount("requests/1") == 0 {
+				t.Errorf("Expected the request to be polled")
+			}
+		})
+	})
+
+	t.Run("when a poll hangs", func(t *testing.T) {
+		polled := make(chan bool, 1)
+		release := make(chan bool)
+
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case polled <- true:
+			default:
+			}
+
+			<-release
+		}))
+		defer api.Close()
+		defer close(release)
+
+		driver, _ := client.New(api.URL, "sekrit")
+		request, _ := Parse([]byte(pending))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() {
+			_, err := Wait(ctx, driver, request, time.Millisecond, nil)
+			done <- err
+		}()
+
+		<-polled
+		cancel()
+
+		t.Run("cancelling the context abandons the poll", func(t *testing.T) {
+			select {
+			case err := <-done:
+				if err == nil {
+					t.Errorf("Expected an error")
+				}
+			case <-time.After(2 * time.Second):
+				t.Errorf("Expected Wait to return")
+			}
+		})
+	})
+}
@@ -0,0 +1,50 @@
+// Package servers provides the data structures and functions for modeling
+// the Servers endpoint on the Engine Yard API
+package servers
+
+import (
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to an upstream Server
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Server Details
+	Enabled         bool   `json:"enabled,omitempty"`
+	Flavor          string `json:"flavor,omitempty"`
+	Location        string `json:"location,omitempty"`
+	Name            string `json:"name,omitempty"`
+	PrivateHostname string `json:"private_hostname,omitempty"`
+	ProvisionedID   string `json:"provisioned_id,omitempty"`
+	PublicHostname  string `json:"public_hostname,omitempty"`
+	Role            Role   `json:"role,omitempty"`
+	State           string `json:"state,omitempty"`
+
+	// Relation URLs
+	Account     string `json:"account,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	Events      string `json:"events,omitempty"`
+	Snapshots   string `json:"snapshots,omitempty"`
+	Volumes     string `json:"volumes,omitempty"`
+
+	// Timestamps
+	CreatedAt       timestamp.Time `json:"created_at,omitempty"`
+	DeprovisionedAt timestamp.Time `json:"deprovisioned_at,omitempty"`
+	ProvisionedAt   timestamp.Time `json:"provisioned_at,omitempty"`
+	UpdatedAt       timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,106 @@
+package servers
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/environments"
+)
+
+// Reader provides an interface for the finder functions to talk to the API
+type Reader interface {
+	Get(string, url.Values) ([]byte, error)
+}
+
+// All returns an array of server entities from the API. If params are
+// provided, they are passed along to the API for consideration. If there are
+// problems along the way, a non-nil error is returned.
+func All(driver Reader, params url.Values) ([]*Entity, error) {
+	return allPages(driver, "servers", params)
+}
+
+// ForAccount returns an array of server entities from the API in the given
+// account. If params are provided, they are passed along to the API for
+// consideration. If there are problems along the way, a non-nil error is
+// returned.
+func ForAccount(driver Reader, account *accounts.Entity, params url.Values) ([]*Entity, error) {
+	pathParts := []string{"accounts", account.ID, "servers"}
+
+	return allPages(driver, strings.Join(pathParts, "/"), params)
+}
+
+// ForEnvironment returns an array of server entities from the API in the given
+// environment. If params are provided, they are passed along to the API for
+// consideration. If there are problems along the way, a non-nil error is
+// returned.
+func ForEnvironment(driver Reader, environment *environments.Entity, params url.Values) ([]*Entity, error) {
+	pathParts := []string{"environments", environment.ID, "servers"}
+
+	return allPages(driver, strings.Join(pathParts, "/"), params)
+}
+
+// Find queries the API for a single server entity by server ID. If
+// there are problems along the way, a non-nil error is returned. Otherwise,
+// the error is nil and the entity is populated.
+func Find(driver Reader, id string) (*Entity, error) {
+	response, err := driver.Get("servers/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(response)
+}
+
+func parse(response []byte) (*Entity, error) {
+	wrapper := struct {
+		Server *Entity `json:"server,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Server, nil
+}
+
+func allPages(driver Reader, path string, params url.Values) ([]*Entity, error) {
+	var servers []*Entity
+
+	err := client.EachPage(driver, path, params, func(response []byte) (int, error) {
+		wrapper := struct {
+			Servers []*Entity `json:"servers,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(response, &wrapper); err != nil {
+			return 0, err
+		}
+
+		servers = append(servers, wrapper.Servers...)
+
+		return len(wrapper.Servers), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return servers, nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,142 @@
+package servers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+)
+
+func TestForAccount(t *testing.T) {
+	path := "accounts/1/servers"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"servers" : [{"id" : "1"}, {"id" : "2"}]}`)
+
+		result, err := ForAccount(driver, &accounts.Entity{ID: "1"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the servers in the account", func(t *testing.T) {
+			if len(result) != 2 {
+				t.Errorf("Expected 2 servers")
+			}
+		})
+	})
+
+	t.Run("when there are several pages", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, maurytest.Page(1, nil), maurytest.Collection("servers", 1, 100))
+		driver.Respond("GET", path, maurytest.Page(2, nil), maurytest.Collection("servers", 101, 102))
+
+		result, err := ForAccount(driver, &accounts.Entity{ID: "1"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the servers from every page", func(t *testing.T) {
+			if len(result) != 102 {
+				t.Errorf("Expected 102 servers, got %d", len(result))
+			}
+		})
+	})
+}
+
+func TestForEnvironment(t *testing.T) {
+	path := "environments/2/servers"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"servers" : [{"id" : "3", "role" : "app_master"}]}`)
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the servers in the environment", func(t *testing.T) {
+			if len(result) != 1 || result[0].Role != "app_master" {
+				t.Errorf("Expected only the app master")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, maurytest.Page(1, nil), maurytest.Collection("servers", 1, 100))
+		driver.Fail("GET", path, maurytest.Page(2, nil), errors.New("nope"))
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("there are no results", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected no results")
+			}
+		})
+
+		t.Run("it stops requesting pages", func(t *testing.T) {
+			if calls := driver.CallCount(path); calls != 2 {
+				t.Errorf("Expected 2 calls, got %d", calls)
+			}
+		})
+	})
+}
+
+func TestFind(t *testing.T) {
+	t.Run("when the server exists", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "servers/1", nil, `{"server" : {"id" : "1", "provisioned_id" : "i-abc123", "flavor" : "m5.large"}}`)
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is populated", func(t *testing.T) {
+			if result == nil || result.ProvisionedID != "i-abc123" || result.Flavor != "m5.large" {
+				t.Errorf("Expected server i-abc123")
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "servers/1", nil, errors.New("nope"))
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if result != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+	})
+}
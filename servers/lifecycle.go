@@ -0,0 +1,196 @@
+package servers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/requests"
+)
+
+// maxIOPSPerGB is the most provisioned IOPS that a volume can have for each
+// gigabyte of its size
+const maxIOPSPerGB = 50
+
+// ErrMissingSpec is returned when a server is added without a spec
+var ErrMissingSpec = errors.New("A spec is required to add a server")
+
+// ErrMissingName is returned when a utility server is added without a name
+var ErrMissingName = errors.New("A name is required to add a utility server")
+
+// InvalidRoleError is returned when a server can't be added with the
+// requested role
+type InvalidRoleError struct {
+	Role Role
+}
+
+func (err *InvalidRoleError) Error() string {
+	return "Servers with the role '" + string(err.Role) + "' can't be added to an environment"
+}
+
+// InvalidVolumeError is returned when the volume requested for a new server
+// is inconsistent
+type InvalidVolumeError struct {
+	Reason string
+}
+
+func (err *InvalidVolumeError) Error() string {
+	return "Invalid volume: " + err.Reason
+}
+
+// Operator provides an interface for the lifecycle functions to talk to the
+// API
+type Operator interface {
+	Reader
+	Post(string, url.Values, []byte) ([]byte, error)
+	Delete(string, url.Values) ([]byte, error)
+}
+
+// Spec describes a server to add to an environment. Volume sizes are in
+// gigabytes.
+type Spec struct {
+	Flavor     string `json:"flavor,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Role       Role   `json:"role,omitempty"`
+	VolumeIOPS int    `json:"volume_iops,omitempty"`
+	VolumeSize int    `json:"volume_size,omitempty"`
+}
+
+// Validate checks that a server matching the spec can be added to an
+// environment. If it can't, the error describes why.
+func (spec *Spec) Validate() error {
+	if !spec.Role.Addable() {
+		return &InvalidRoleError{Role: spec.Role}
+	}
+
+	if spec.Role == RoleUtil && len(spec.Name) == 0 {
+		return ErrMissingName
+	}
+
+	if err := ValidateFlavor(spec.Role, spec.Flavor); err != nil {
+		return err
+	}
+
+	if spec.VolumeSize < 0 || spec.VolumeIOPS < 0 {
+		return &InvalidVolumeError{Reason: "sizes and IOPS can't be negative"}
+	}
+
+	if spec.VolumeIOPS > 0 && spec.VolumeSize == 0 {
+		return &InvalidVolumeError{Reason: "IOPS can only be provisioned with an explicit size"}
+	}
+
+	if spec.VolumeIOPS > spec.VolumeSize*maxIOPSPerGB {
+		return &InvalidVolumeError{Reason: "at most " + strconv.Itoa(maxIOPSPerGB) + " IOPS can be provisioned per GB"}
+	}
+
+	return nil
+}
+
+// Add requests that a server matching the given spec be added to the given
+// environment. If the spec is nil, the error is ErrMissingSpec. The spec is
+// validated before anything is sent to the API. If there are problems along
+// the way, a non-nil error is returned. Otherwise, the error is nil and the
+// async request that tracks the new server is returned.
+func Add(driver Operator, environment *environments.Entity, spec *Spec) (*requests.Entity, error) {
+	if spec == nil {
+		return nil, ErrMissingSpec
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	wrapped := struct {
+		Server *Spec `json:"server"`
+	}{
+		Server: spec,
+	}
+
+	data, err := json.Marshal(&wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	pathParts := []string{"environments", environment.ID, "servers"}
+
+	return request(driver.Post(strings.Join(pathParts, "/"), nil, data))
+}
+
+// Terminate requests that the given server be removed from its environment
+// and destroyed. If there are problems along the way, a non-nil error is
+// returned. Otherwise, the error is nil and the async request that tracks the
+// termination is returned.
+func Terminate(driver Operator, server *Entity) (*requests.Entity, error) {
+	return request(driver.Delete("servers/"+server.ID, nil))
+}
+
+// Reboot requests that the given server be restarted. If there are problems
+// along the way, a non-nil error is returned. Otherwise, the error is nil and
+// the async request that tracks the reboot is returned.
+func Reboot(driver Operator, server *Entity) (*requests.Entity, error) {
+	return perform(driver, server, "reboot", nil)
+}
+
+// Stop requests that the given server be shut down without being destroyed.
+// If there are problems along the way, a non-nil error is returned.
+// Otherwise, the error is nil and the async request that tracks the shutdown
+// is returned.
+func Stop(driver Operator, server *Entity) (*requests.Entity, error) {
+	return perform(driver, server, "stop", nil)
+}
+
+// Start requests that the given stopped server be started again. If there are
+// problems along the way, a non-nil error is returned. Otherwise, the error
+// is nil and the async request that tracks the startup is returned.
+func Start(driver Operator, server *Entity) (*requests.Entity, error) {
+	return perform(driver, server, "start", nil)
+}
+
+// ChangeFlavor requests that the given server be resized to the given flavor.
+// The flavor is validated against the server's role before anything is sent
+// to the API, and if it can't be used, the error is an *InvalidFlavorError.
+// Otherwise, the error is nil and the async request that tracks the resize is
+// returned.
+func ChangeFlavor(driver Operator, server *Entity, flavor string) (*requests.Entity, error) {
+	if err := ValidateFlavor(server.Role, flavor); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(map[string]string{"flavor": flavor})
+	if err != nil {
+		return nil, err
+	}
+
+	return perform(driver, server, "resize", data)
+}
+
+func perform(driver Operator, server *Entity, action string, data []byte) (*requests.Entity, error) {
+	pathParts := []string{"servers", server.ID, action}
+
+	return request(driver.Post(strings.Join(pathParts, "/"), nil, data))
+}
+
+func request(response []byte, err error) (*requests.Entity, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return requests.Parse(response)
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,224 @@
+package servers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+)
+
+const accepted = `{"request" : {"id" : "5"}}`
+
+func TestSpec_Validate(t *testing.T) {
+	valid := []*Spec{
+		{Role: RoleApp, Flavor: "m5.large"},
+		{Role: RoleUtil, Name: "redis", Flavor: "r5.large", VolumeSize: 50},
+		{Role: RoleDBSlave, Flavor: "m5.xlarge", VolumeSize: 100, VolumeIOPS: 5000},
+	}
+
+	t.Run("when the spec is valid", func(t *testing.T) {
+		for _, spec := range valid {
+			if err := spec.Validate(); err != nil {
+				t.Errorf("Expected %+v to be valid, got %s", spec, err)
+			}
+		}
+	})
+
+	t.Run("when the role can't be added", func(t *testing.T) {
+		spec := &Spec{Role: RoleDBMaster, Flavor: "m5.large"}
+
+		if _, ok := spec.Validate().(*InvalidRoleError); !ok {
+			t.Errorf("Expected an *InvalidRoleError")
+		}
+	})
+
+	t.Run("when a utility server has no name", func(t *testing.T) {
+		spec := &Spec{Role: RoleUtil, Flavor: "m5.large"}
+
+		if spec.Validate() != ErrMissingName {
+			t.Errorf("Expected ErrMissingName")
+		}
+	})
+
+	t.Run("when the flavor does not suit the role", func(t *testing.T) {
+		spec := &Spec{Role: RoleDBSlave, Flavor: "t2.large"}
+
+		if _, ok := spec.Validate().(*InvalidFlavorError); !ok {
+			t.Errorf("Expected an *InvalidFlavorError")
+		}
+	})
+
+	t.Run("when IOPS are requested without a size", func(t *testing.T) {
+		spec := &Spec{Role: RoleApp, Flavor: "m5.large", VolumeIOPS: 1000}
+
+		if _, ok := spec.Validate().(*InvalidVolumeError); !ok {
+			t.Errorf("Expected an *InvalidVolumeError")
+		}
+	})
+
+	t.Run("when too many IOPS are requested for the size", func(t *testing.T) {
+		spec := &Spec{Role: RoleApp, Flavor: "m5.large", VolumeSize: 10, VolumeIOPS: 1000}
+
+		if _, ok := spec.Validate().(*InvalidVolumeError); !ok {
+			t.Errorf("Expected an *InvalidVolumeError")
+		}
+	})
+}
+
+func TestAdd(t *testing.T) {
+	environment := &environments.Entity{ID: "2"}
+
+	t.Run("when the spec is valid", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "environments/2/servers", nil, accepted)
+
+		request, err := Add(driver, environment, &Spec{Role: RoleUtil, Name: "redis", Flavor: "r5.large", VolumeSize: 50})
+
+		t.Run("it returns the async request", func(t *testing.T) {
+			if err != nil || request == nil || request.ID != "5" {
+				t.Errorf("Expected request 5, got error %v", err)
+			}
+		})
+
+		t.Run("it sends the spec", func(t *testing.T) {
+			wrapper := struct {
+				Server *Spec `json:"server"`
+			}{}
+
+			json.Unmarshal(driver.Calls()[0].Data, &wrapper)
+
+			if wrapper.Server == nil || wrapper.Server.Role != RoleUtil || wrapper.Server.Name != "redis" || wrapper.Server.VolumeSize != 50 {
+				t.Errorf("Unexpected server %+v", wrapper.Server)
+			}
+		})
+	})
+
+	t.Run("when the spec is invalid", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		request, err := Add(driver, environment, &Spec{Role: RoleSolo, Flavor: "m5.large"})
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || request != nil {
+				t.Errorf("Expected an error and no request")
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environments/2/servers")
+		})
+	})
+
+	t.Run("when there is no spec", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		request, err := Add(driver, environment, nil)
+
+		t.Run("the error is ErrMissingSpec", func(t *testing.T) {
+			if err != ErrMissingSpec || request != nil {
+				t.Errorf("Expected ErrMissingSpec and no request, got %v", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "environments/2/servers")
+		})
+	})
+}
+
+func TestTerminate(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("DELETE", "servers/1", nil, accepted)
+
+	request, err := Terminate(driver, &Entity{ID: "1"})
+
+	t.Run("it returns the async request", func(t *testing.T) {
+		if err != nil || request == nil || request.ID != "5" {
+			t.Errorf("Expected request 5, got error %v", err)
+		}
+	})
+}
+
+func TestPowerActions(t *testing.T) {
+	server := &Entity{ID: "1", Role: RoleApp}
+
+	actions := map[string]func(Operator, *Entity) error{
+		"reboot": func(driver Operator, server *Entity) error { _, err := Reboot(driver, server); return err },
+		"stop":   func(driver Operator, server *Entity) error { _, err := Stop(driver, server); return err },
+		"start":  func(driver Operator, server *Entity) error { _, err := Start(driver, server); return err },
+	}
+
+	for action, perform := range actions {
+		t.Run("when the server is asked to "+action, func(t *testing.T) {
+			driver := maurytest.NewDriver()
+			driver.Respond("POST", "servers/1/"+action, nil, accepted)
+
+			err := perform(driver, server)
+
+			t.Run("it has no error", func(t *testing.T) {
+				if err != nil {
+					t.Errorf("Expected no error, got %s", err)
+				}
+			})
+
+			t.Run("it calls the action endpoint", func(t *testing.T) {
+				driver.AssertCalled(t, "servers/1/"+action, nil)
+			})
+		})
+	}
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("POST", "servers/1/reboot", nil, errors.New("nope"))
+
+		request, err := Reboot(driver, server)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || request != nil {
+				t.Errorf("Expected an error and no request")
+			}
+		})
+	})
+}
+
+func TestChangeFlavor(t *testing.T) {
+	t.Run("when the flavor suits the server", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "servers/1/resize", nil, accepted)
+
+		request, err := ChangeFlavor(driver, &Entity{ID: "1", Role: RoleDBMaster}, "m5.2xlarge")
+
+		t.Run("it returns the async request", func(t *testing.T) {
+			if err != nil || request == nil || request.ID != "5" {
+				t.Errorf("Expected request 5, got error %v", err)
+			}
+		})
+
+		t.Run("it sends the flavor", func(t *testing.T) {
+			body := make(map[string]string)
+			json.Unmarshal(driver.Calls()[0].Data, &body)
+
+			if body["flavor"] != "m5.2xlarge" {
+				t.Errorf("Expected m5.2xlarge, got '%s'", body["flavor"])
+			}
+		})
+	})
+
+	t.Run("when the flavor does not suit the server", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+
+		_, err := ChangeFlavor(driver, &Entity{ID: "1", Role: RoleDBMaster}, "t3.large")
+
+		t.Run("the error is an InvalidFlavorError", func(t *testing.T) {
+			if _, ok := err.(*InvalidFlavorError); !ok {
+				t.Errorf("Expected an *InvalidFlavorError, got %T", err)
+			}
+		})
+
+		t.Run("it does not contact the API", func(t *testing.T) {
+			driver.AssertNotCalled(t, "servers/1/resize")
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package servers
+
+import (
+	"strings"
+)
+
+// Role is the part that a server plays in its environment. The API may
+// introduce roles that are not listed here, and those values are retained
+// as-is.
+type Role string
+
+// Known server roles
+const (
+	RoleSolo      Role = "solo"
+	RoleAppMaster Role = "app_master"
+	RoleApp       Role = "app"
+	RoleDBMaster  Role = "db_master"
+	RoleDBSlave   Role = "db_slave"
+	RoleUtil      Role = "util"
+)
+
+// Known returns true if the role is one of the known server roles
+func (role Role) Known() bool {
+	switch role {
+	case RoleSolo, RoleAppMaster, RoleApp, RoleDBMaster, RoleDBSlave, RoleUtil:
+		return true
+	}
+
+	return false
+}
+
+// Addable returns true if servers with the role can be added to a running
+// environment. The solo, app master and database master servers are created
+// when the environment boots and can't be added later.
+func (role Role) Addable() bool {
+	switch role {
+	case RoleApp, RoleDBSlave, RoleUtil:
+		return true
+	}
+
+	return false
+}
+
+// Database returns true if servers with the role run the environment's
+// database
+func (role Role) Database() bool {
+	return role == RoleDBMaster || role == RoleDBSlave
+}
+
+// burstable lists the instance families whose CPU is throttled once their
+// credits run out, which makes them unsuitable for databases
+var burstable = map[string]bool{
+	"t2":  true,
+	"t3":  true,
+	"t3a": true,
+	"t4g": true,
+}
+
+// InvalidFlavorError is returned when a flavor is malformed or can't be used
+// for a server's role
+type InvalidFlavorError struct {
+	Role   Role
+	Flavor string
+	Reason string
+}
+
+func (err *InvalidFlavorError) Error() string {
+	return "Flavor '" + err.Flavor + "' can't be used for a " + string(err.Role) + " server: " + err.Reason
+}
+
+// ValidateFlavor checks that the flavor is a well-formed instance type, like
+// m5.large, that can be used for a server with the given role. If it isn't,
+// the error is an *InvalidFlavorError.
+func ValidateFlavor(role Role, flavor string) error {
+	parts := strings.Split(flavor, ".")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return &InvalidFlavorError{Role: role, Flavor: flavor, Reason: "flavors look like family.size"}
+	}
+
+	if role.Database() && burstable[parts[0]] {
+		return &InvalidFlavorError{Role: role, Flavor: flavor, Reason: "burstable instances can't run databases"}
+	}
+
+	return nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,47 @@
+package servers
+
+import (
+	"testing"
+)
+
+func TestRole_Known(t *testing.T) {
+	t.Run("it knows the documented roles", func(t *testing.T) {
+		for _, role := range []Role{RoleSolo, RoleAppMaster, RoleApp, RoleDBMaster, RoleDBSlave, RoleUtil} {
+			if !role.Known() {
+				t.Errorf("Expected %s to be known", role)
+			}
+		}
+	})
+
+	t.Run("it does not know other roles", func(t *testing.T) {
+		if Role("load_balancer").Known() {
+			t.Errorf("Expected load_balancer to be unknown")
+		}
+	})
+}
+
+func TestValidateFlavor(t *testing.T) {
+	t.Run("when the flavor suits the role", func(t *testing.T) {
+		if err := ValidateFlavor(RoleDBSlave, "m5.xlarge"); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+
+		if err := ValidateFlavor(RoleApp, "t3.medium"); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("when the flavor is malformed", func(t *testing.T) {
+		for _, flavor := range []string{"", "large", "m5.", ".large", "m5.large.extra"} {
+			if _, ok := ValidateFlavor(RoleApp, flavor).(*InvalidFlavorError); !ok {
+				t.Errorf("Expected an *InvalidFlavorError for '%s'", flavor)
+			}
+		}
+	})
+
+	t.Run("when a database would run on a burstable instance", func(t *testing.T) {
+		if _, ok := ValidateFlavor(RoleDBMaster, "t3.large").(*InvalidFlavorError); !ok {
+			t.Errorf("Expected an *InvalidFlavorError")
+		}
+	})
+}
@@ -0,0 +1,63 @@
+// Package snapshots provides the data structures and functions for modeling
+// the Snapshots endpoint on the Engine Yard API. Snapshots are point-in-time
+// copies of volumes.
+package snapshots
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to an upstream Snapshot
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Snapshot Details
+	Encrypted     bool   `json:"encrypted,omitempty"`
+	Progress      string `json:"progress,omitempty"`
+	ProvisionedID string `json:"provisioned_id,omitempty"`
+	Size          int    `json:"size,omitempty"`
+	State         string `json:"state,omitempty"`
+
+	// Relation URLs
+	Server string `json:"server,omitempty"`
+	Volume string `json:"volume,omitempty"`
+
+	// Timestamps
+	CreatedAt timestamp.Time `json:"created_at,omitempty"`
+	DeletedAt timestamp.Time `json:"deleted_at,omitempty"`
+	UpdatedAt timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Percent returns the progress of the snapshot as a whole percentage. The API
+// reports progress as a string like "45%", and a progress that can't be read
+// is treated as 0.
+func (snapshot *Entity) Percent() int {
+	percent, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(snapshot.Progress, "%")))
+	if err != nil {
+		return 0
+	}
+
+	return percent
+}
+
+// Completed returns true if the snapshot has been fully taken
+func (snapshot *Entity) Completed() bool {
+	return snapshot.State == "completed" || snapshot.Percent() >= 100
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,43 @@
+package snapshots
+
+import (
+	"testing"
+)
+
+func TestEntity_Completed(t *testing.T) {
+	t.Run("when the snapshot is in progress", func(t *testing.T) {
+		snapshot := &Entity{Progress: "45%", State: "pending"}
+
+		t.Run("the progress is read as a percentage", func(t *testing.T) {
+			if snapshot.Percent() != 45 {
+				t.Errorf("Expected 45, got %d", snapshot.Percent())
+			}
+		})
+
+		t.Run("it is not completed", func(t *testing.T) {
+			if snapshot.Completed() {
+				t.Errorf("Expected the snapshot not to be completed")
+			}
+		})
+	})
+
+	t.Run("when the snapshot has finished", func(t *testing.T) {
+		snapshot := &Entity{Progress: "100%"}
+
+		t.Run("it is completed", func(t *testing.T) {
+			if !snapshot.Completed() {
+				t.Errorf("Expected the snapshot to be completed")
+			}
+		})
+	})
+
+	t.Run("when the progress can't be read", func(t *testing.T) {
+		snapshot := &Entity{Progress: "soon"}
+
+		t.Run("the percentage is 0", func(t *testing.T) {
+			if snapshot.Percent() != 0 {
+				t.Errorf("Expected 0, got %d", snapshot.Percent())
+			}
+		})
+	})
+}
@@ -0,0 +1,128 @@
+package snapshots
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/client"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/servers"
+	"github.com/ess/maury/volumes"
+)
+
+// Reader provides an interface for the finder functions to talk to the API
+type Reader interface {
+	Get(string, url.Values) ([]byte, error)
+}
+
+// All returns an array of snapshot entities from the API. If params are
+// provided, they are passed along to the API for consideration. If there are
+// problems along the way, a non-nil error is returned.
+func All(driver Reader, params url.Values) ([]*Entity, error) {
+	return allPages(driver, "snapshots", params)
+}
+
+// ForVolume returns an array of snapshot entities from the API taken of the
+// given volume. If params are provided, they are passed along to the API for
+// consideration. If there are problems along the way, a non-nil error is
+// returned.
+func ForVolume(driver Reader, volume *volumes.Entity, params url.Values) ([]*Entity, error) {
+	pathParts := []string{"volumes", volume.ID, "snapshots"}
+
+	return allPages(driver, strings.Join(pathParts, "/"), params)
+}
+
+// ForServer returns an array of snapshot entities from the API taken of the
+// volumes of the given server. If params are provided, they are passed along
+// to the API for consideration. If there are problems along the way, a non-nil
+// error is returned.
+func ForServer(driver Reader, server *servers.Entity, params url.Values) ([]*Entity, error) {
+	pathParts := []string{"servers", server.ID, "snapshots"}
+
+	return allPages(driver, strings.Join(pathParts, "/"), params)
+}
+
+// ForEnvironment returns an array of snapshot entities from the API in the
+// given environment. If params are provided, they are passed along to the API
+// for consideration. If there are problems along the way, a non-nil error is
+// returned.
+func ForEnvironment(driver Reader, environment *environments.Entity, params url.Values) ([]*Entity, error) {
+	pathParts := []string{"environments", environment.ID, "snapshots"}
+
+	return allPages(driver, strings.Join(pathParts, "/"), params)
+}
+
+// ForAccount returns an array of snapshot entities from the API in the given
+// account. If params are provided, they are passed along to the API for
+// consideration. If there are problems along the way, a non-nil error is
+// returned.
+func ForAccount(driver Reader, account *accounts.Entity, params url.Values) ([]*Entity, error) {
+	pathParts := []string{"accounts", account.ID, "snapshots"}
+
+	return allPages(driver, strings.Join(pathParts, "/"), params)
+}
+
+// Find queries the API for a single snapshot entity by snapshot ID. If
+// there are problems along the way, a non-nil error is returned. Otherwise,
+// the error is nil and the entity is populated.
+func Find(driver Reader, id string) (*Entity, error) {
+	response, err := driver.Get("snapshots/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(response)
+}
+
+func parse(response []byte) (*Entity, error) {
+	wrapper := struct {
+		Snapshot *Entity `json:"snapshot,omitempty"`
+	}{}
+
+	err := json.Unmarshal(response, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapper.Snapshot, nil
+}
+
+func allPages(driver Reader, path string, params url.Values) ([]*Entity, error) {
+	var snapshots []*Entity
+
+	err := client.EachPage(driver, path, params, func(response []byte) (int, error) {
+		wrapper := struct {
+			Snapshots []*Entity `json:"snapshots,omitempty"`
+		}{}
+
+		if err := json.Unmarshal(response, &wrapper); err != nil {
+			return 0, err
+		}
+
+		snapshots = append(snapshots, wrapper.Snapshots...)
+
+		return len(wrapper.Snapshots), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
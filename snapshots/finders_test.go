@@ -0,0 +1,157 @@
+package snapshots
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+	"github.com/ess/maury/servers"
+	"github.com/ess/maury/volumes"
+)
+
+func TestForVolume(t *testing.T) {
+	path := "volumes/1/snapshots"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"snapshots" : [{"id" : "1"}, {"id" : "2"}]}`)
+
+		result, err := ForVolume(driver, &volumes.Entity{ID: "1"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the snapshots of the volume", func(t *testing.T) {
+			if len(result) != 2 {
+				t.Errorf("Expected 2 snapshots")
+			}
+		})
+	})
+
+	t.Run("when there are several pages", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, maurytest.Page(1, nil), maurytest.Collection("snapshots", 1, 100))
+		driver.Respond("GET", path, maurytest.Page(2, nil), maurytest.Collection("snapshots", 101, 102))
+
+		result, err := ForVolume(driver, &volumes.Entity{ID: "1"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the snapshots from every page", func(t *testing.T) {
+			if len(result) != 102 {
+				t.Errorf("Expected 102 snapshots, got %d", len(result))
+			}
+		})
+	})
+}
+
+func TestForServer(t *testing.T) {
+	path := "servers/1/snapshots"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"snapshots" : [{"id" : "3"}]}`)
+
+		result, err := ForServer(driver, &servers.Entity{ID: "1"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the snapshots for the server", func(t *testing.T) {
+			if len(result) != 1 || result[0].ID != "3" {
+				t.Errorf("Expected only snapshot 3")
+			}
+		})
+	})
+}
+
+func TestForEnvironment(t *testing.T) {
+	path := "environments/2/snapshots"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"snapshots" : [{"id" : "4"}]}`)
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the snapshots in the environment", func(t *testing.T) {
+			if len(result) != 1 || result[0].ID != "4" {
+				t.Errorf("Expected only snapshot 4")
+			}
+		})
+	})
+}
+
+func TestFind(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("GET", "snapshots/1", nil, `{"snapshot" : {"id" : "1", "progress" : "100%", "volume" : "https://api.engineyard.com/volumes/1", "created_at" : "2018-01-01T00:00:00Z"}}`)
+
+	result, err := Find(driver, "1")
+
+	t.Run("it has no error", func(t *testing.T) {
+		if err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+
+	t.Run("it is populated", func(t *testing.T) {
+		if result == nil || !result.Completed() || result.CreatedAt.IsZero() {
+			t.Errorf("Expected a completed snapshot with a creation time")
+		}
+	})
+}
+
+func TestTotalsForAccount(t *testing.T) {
+	t.Run("when the snapshots can be listed", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "accounts/1/snapshots", nil, `{"snapshots" : [
+			{"id" : "1", "size" : 100, "progress" : "100%"},
+			{"id" : "2", "size" : 25, "progress" : "10%"}
+		]}`)
+
+		totals, err := TotalsForAccount(driver, &accounts.Entity{ID: "1"})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it summarises the snapshots", func(t *testing.T) {
+			if totals.Count != 2 || totals.Completed != 1 || totals.InProgress != 1 || totals.Size != 125 {
+				t.Errorf("Unexpected totals %+v", totals)
+			}
+		})
+	})
+
+	t.Run("when the snapshots can't be listed", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "accounts/1/snapshots", nil, errors.New("nope"))
+
+		totals, err := TotalsForAccount(driver, &accounts.Entity{ID: "1"})
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || totals != nil {
+				t.Errorf("Expected an error and no totals")
+			}
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package snapshots
+
+import (
+	"github.com/ess/maury/accounts"
+)
+
+// Totals summarises a set of snapshots. Sizes are in gigabytes.
+type Totals struct {
+	Count      int
+	Completed  int
+	InProgress int
+	Size       int
+}
+
+// Summarize adds up the given snapshots
+func Summarize(snapshots []*Entity) *Totals {
+	totals := &Totals{}
+
+	for _, snapshot := range snapshots {
+		totals.Count++
+		totals.Size += snapshot.Size
+
+		if snapshot.Completed() {
+			totals.Completed++
+		} else {
+			totals.InProgress++
+		}
+	}
+
+	return totals
+}
+
+// TotalsForAccount summarises all of the snapshots in the given account. If
+// the snapshots can't be listed, a non-nil error is returned rather than
+// totals that leave some out.
+func TotalsForAccount(driver Reader, account *accounts.Entity) (*Totals, error) {
+	snapshots, err := ForAccount(driver, account, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return Summarize(snapshots), nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
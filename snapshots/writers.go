@@ -0,0 +1,53 @@
+package snapshots
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/ess/maury/requests"
+	"github.com/ess/maury/volumes"
+)
+
+// Writer provides an interface for the functions that change snapshots to
+// talk to the API
+type Writer interface {
+	Reader
+	Post(string, url.Values, []byte) ([]byte, error)
+	Delete(string, url.Values) ([]byte, error)
+}
+
+// Create requests that a snapshot be taken of the given volume. If there are
+// problems along the way, a non-nil error is returned. Otherwise, the error is
+// nil and the async request that tracks the snapshot is returned.
+func Create(driver Writer, volume *volumes.Entity) (*requests.Entity, error) {
+	pathParts := []string{"volumes", volume.ID, "snapshots"}
+
+	response, err := driver.Post(strings.Join(pathParts, "/"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return requests.Parse(response)
+}
+
+// Delete requests that the given snapshot be removed. If there are issues
+// along the way, a non-nil error is returned.
+func Delete(driver Writer, snapshot *Entity) error {
+	_, err := driver.Delete("snapshots/"+snapshot.ID, nil)
+
+	return err
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
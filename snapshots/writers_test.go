@@ -0,0 +1,48 @@
+package snapshots
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/maurytest"
+	"github.com/ess/maury/volumes"
+)
+
+func TestCreate(t *testing.T) {
+	t.Run("when the snapshot is accepted", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("POST", "volumes/1/snapshots", nil, `{"request" : {"id" : "5"}}`)
+
+		request, err := Create(driver, &volumes.Entity{ID: "1"})
+
+		t.Run("it returns the async request", func(t *testing.T) {
+			if err != nil || request == nil || request.ID != "5" {
+				t.Errorf("Expected request 5, got error %v", err)
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("POST", "volumes/1/snapshots", nil, errors.New("nope"))
+
+		request, err := Create(driver, &volumes.Entity{ID: "1"})
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || request != nil {
+				t.Errorf("Expected an error and no request")
+			}
+		})
+	})
+}
+
+func TestDelete(t *testing.T) {
+	driver := maurytest.NewDriver()
+	driver.Respond("DELETE", "snapshots/1", nil, ``)
+
+	t.Run("it deletes the snapshot", func(t *testing.T) {
+		if err := Delete(driver, &Entity{ID: "1"}); err != nil {
+			t.Errorf("Expected no error, got %s", err)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+// Package timestamp provides a time type that understands the way that the
+// Engine Yard API represents timestamps
+package timestamp
+
+import (
+	"bytes"
+	"time"
+)
+
+// Time is a wrapper around time.Time that knows how to handle the RFC3339
+// timestamps sent by the upstream API, as well as the null and empty values
+// that it sends for timestamps that are not set.
+type Time struct {
+	time.Time
+}
+
+// New returns a Time for the given time.Time
+func New(t time.Time) Time {
+	return Time{t}
+}
+
+// Parse takes a timestamp string from the API and returns the Time that it
+// represents. Empty strings and "null" result in a zero Time. If the string
+// cannot be parsed, a non-nil error is returned.
+func Parse(value string) (Time, error) {
+	if len(value) == 0 || value == "null" {
+		return Time{}, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return Time{}, err
+	}
+
+	return Time{parsed}, nil
+}
+
+// String returns the timestamp formatted as RFC3339. If the timestamp is not
+// set, an empty string is returned.
+func (t Time) String() string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// MarshalJSON converts the timestamp into its JSON representation. A zero
+// timestamp is represented as null.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return []byte(`"` + t.Format(time.RFC3339Nano) + `"`), nil
+}
+
+// UnmarshalJSON populates the timestamp from its JSON representation. Null and
+// empty values result in a zero timestamp.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	parsed, err := Parse(string(bytes.Trim(data, `"`)))
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+
+	return nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
@@ -0,0 +1,140 @@
+package timestamp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("when given an RFC3339 timestamp", func(t *testing.T) {
+		parsed, err := Parse("2018-06-01T12:30:00Z")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it has the expected time", func(t *testing.T) {
+			expected := time.Date(2018, 6, 1, 12, 30, 0, 0, time.UTC)
+
+			if !parsed.Equal(expected) {
+				t.Errorf("Expected %s, got %s", expected, parsed)
+			}
+		})
+	})
+
+	t.Run("when given an empty string", func(t *testing.T) {
+		parsed, err := Parse("")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is zero", func(t *testing.T) {
+			if !parsed.IsZero() {
+				t.Errorf("Expected a zero time")
+			}
+		})
+	})
+
+	t.Run("when given garbage", func(t *testing.T) {
+		_, err := Parse("the day after tomorrow")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	wrapper := struct {
+		CreatedAt Time `json:"created_at"`
+	}{}
+
+	t.Run("when the value is a timestamp", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`{"created_at" : "2018-06-01T12:30:00+00:00"}`), &wrapper)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is populated", func(t *testing.T) {
+			if wrapper.CreatedAt.Year() != 2018 {
+				t.Errorf("Expected the year to be 2018, got %d", wrapper.CreatedAt.Year())
+			}
+		})
+	})
+
+	t.Run("when the value is null", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`{"created_at" : null}`), &wrapper)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is zero", func(t *testing.T) {
+			if !wrapper.CreatedAt.IsZero() {
+				t.Errorf("Expected a zero time")
+			}
+		})
+	})
+
+	t.Run("when the value is empty", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`{"created_at" : ""}`), &wrapper)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is zero", func(t *testing.T) {
+			if !wrapper.CreatedAt.IsZero() {
+				t.Errorf("Expected a zero time")
+			}
+		})
+	})
+
+	t.Run("when the value is not a timestamp", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`{"created_at" : "yesterday-ish"}`), &wrapper)
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
+}
+
+func TestTime_MarshalJSON(t *testing.T) {
+	t.Run("when the time is zero", func(t *testing.T) {
+		data, _ := json.Marshal(Time{})
+
+		t.Run("it is null", func(t *testing.T) {
+			if string(data) != "null" {
+				t.Errorf("Expected null, got %s", string(data))
+			}
+		})
+	})
+
+	t.Run("when the time is set", func(t *testing.T) {
+		data, _ := json.Marshal(New(time.Date(2018, 6, 1, 12, 30, 0, 0, time.UTC)))
+		expected := `"2018-06-01T12:30:00Z"`
+
+		t.Run("it is an RFC3339 string", func(t *testing.T) {
+			if string(data) != expected {
+				t.Errorf("Expected %s, got %s", expected, string(data))
+			}
+		})
+	})
+}
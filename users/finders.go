@@ -3,7 +3,8 @@ package users
 import (
 	"encoding/json"
 	"net/url"
-	"strconv"
+
+	"github.com/ess/maury/client"
 )
 
 // Reader provides an interface for the finder functions to talk to the API
@@ -12,8 +13,9 @@ type Reader interface {
 }
 
 // All returns an array of user entities from the API. If params are
-// provided, they are passed along to the API for consideration.
-func All(driver Reader, params url.Values) []*Entity {
+// provided, they are passed along to the API for consideration. If there are
+// problems along the way, a non-nil error is returned.
+func All(driver Reader, params url.Values) ([]*Entity, error) {
 	return allPages(driver, "users", params)
 }
 
@@ -59,41 +61,28 @@ func Current(driver Reader) (*Entity, error) {
   return wrapper.User, nil
 }
 
-func allPages(driver Reader, path string, params url.Values) []*Entity {
+func allPages(driver Reader, path string, params url.Values) ([]*Entity, error) {
 	var users []*Entity
 
-	maxResults := 100
-	page := 1
-
-	if params == nil {
-		params = url.Values{}
-	}
-
-	params.Set("per_page", strconv.Itoa(maxResults))
+	err := client.EachPage(driver, path, params, func(response []byte) (int, error) {
+		wrapper := struct {
+			Users []*Entity `json:"users,omitempty"`
+		}{}
 
-	wrapper := struct {
-		Users []*Entity `json:"users,omitempty"`
-	}{
-		Users: []*Entity{{}},
-	}
-
-	for len(wrapper.Users) > 0 {
-		params.Set("page", strconv.Itoa(page))
+		if err := json.Unmarshal(response, &wrapper); err != nil {
+			return 0, err
+		}
 
-		if response, err := driver.Get(path, params); err == nil {
-			if jerr := json.Unmarshal(response, &wrapper); jerr == nil {
-				users = append(users, wrapper.Users...)
+		users = append(users, wrapper.Users...)
 
-				if len(wrapper.Users) < maxResults {
-					break
-				}
-			}
-		}
+		return len(wrapper.Users), nil
+	})
 
-		page = page + 1
+	if err != nil {
+		return nil, err
 	}
 
-	return users
+	return users, nil
 }
 
 // Copyright 2018 Dennis Walters
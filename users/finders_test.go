@@ -75,7 +75,13 @@ func TestAll(t *testing.T) {
 
 		driver.set("users", params, `{"users" : []}`)
 
-		all := All(driver, nil)
+		all, err := All(driver, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
 
 		t.Run("it is empty", func(t *testing.T) {
 			if len(all) > 0 {
@@ -93,7 +99,7 @@ func TestAll(t *testing.T) {
 
 			driver.set("users", params, generate(1, 10))
 
-			all := All(driver, nil)
+			all, _ := All(driver, nil)
 
 			t.Run("it contains the entities the API returned", func(t *testing.T) {
 				if len(all) != 10 {
@@ -114,7 +120,7 @@ func TestAll(t *testing.T) {
 
 			driver.set("users", params, generate(101, 110))
 
-			all := All(driver, nil)
+			all, _ := All(driver, nil)
 
 			t.Run("it contains all of the entities the API returned", func(t *testing.T) {
 				if len(all) != 110 {
@@ -123,6 +129,24 @@ func TestAll(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("when the API fails", func(t *testing.T) {
+		driver := &reader{}
+
+		all, err := All(driver, nil)
+
+		t.Run("it is empty", func(t *testing.T) {
+			if len(all) > 0 {
+				t.Errorf("Expected an empty array, got one with %d members", len(all))
+			}
+		})
+
+		t.Run("it returns the error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	})
 }
 
 func TestFind(t *testing.T) {
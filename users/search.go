@@ -0,0 +1,103 @@
+package users
+
+import (
+	"net/url"
+	"strings"
+)
+
+// NotFoundError is returned when a search yields no users
+type NotFoundError struct {
+	Query string
+}
+
+func (err *NotFoundError) Error() string {
+	return "No users found matching '" + err.Query + "'"
+}
+
+// FindByEmail queries the API for a single user entity by email address,
+// ignoring case. The API's email filter is used first, and if that yields
+// nothing, all visible users are scanned instead. If the API fails, that
+// error is returned. If no user matches, the error is a *NotFoundError.
+func FindByEmail(driver Reader, email string) (*Entity, error) {
+	params := url.Values{}
+	params.Set("email", email)
+
+	candidates, err := allPages(driver, "users", params)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the API ignored the filter, we've already seen every user and there's
+	// no need to scan them again.
+	if len(candidates) == 0 {
+		candidates, err = All(driver, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, user := range candidates {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+
+	return nil, &NotFoundError{Query: email}
+}
+
+// Search returns all of the users whose name or email address contain every
+// word in the given query, ignoring case. The API has no filter that covers
+// both, so every visible user is scanned. If the API fails, that error is
+// returned. If no user matches, the error is a *NotFoundError.
+func Search(driver Reader, query string) ([]*Entity, error) {
+	candidates, err := All(driver, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := matching(candidates, strings.Fields(strings.ToLower(query)))
+	if len(matches) == 0 {
+		return nil, &NotFoundError{Query: query}
+	}
+
+	return matches, nil
+}
+
+func matching(candidates []*Entity, terms []string) []*Entity {
+	var matches []*Entity
+
+	for _, user := range candidates {
+		if matchesAll(user, terms) {
+			matches = append(matches, user)
+		}
+	}
+
+	return matches
+}
+
+func matchesAll(user *Entity, terms []string) bool {
+	name := strings.ToLower(user.Name)
+	email := strings.ToLower(user.Email)
+
+	for _, term := range terms {
+		if !strings.Contains(name, term) && !strings.Contains(email, term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
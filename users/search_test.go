@@ -0,0 +1,202 @@
+package users
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestFindByEmail(t *testing.T) {
+	email := "Bob@Example.com"
+
+	filtered := url.Values{}
+	filtered.Set("email", email)
+	filtered.Set("page", "1")
+	filtered.Set("per_page", "100")
+
+	unfiltered := url.Values{}
+	unfiltered.Set("page", "1")
+	unfiltered.Set("per_page", "100")
+
+	t.Run("when the API supports the email filter", func(t *testing.T) {
+		driver := &reader{}
+		driver.set("users", filtered, `{"users" : [{"id" : "1", "email" : "bob@example.com"}]}`)
+
+		user, err := FindByEmail(driver, email)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it finds the user", func(t *testing.T) {
+			if user == nil || user.ID != "1" {
+				t.Errorf("Expected user 1")
+			}
+		})
+	})
+
+	t.Run("when the API does not support the email filter", func(t *testing.T) {
+		driver := &reader{}
+		driver.set("users", filtered, `{"users" : []}`)
+		driver.set(
+			"users",
+			unfiltered,
+			`{"users" : [{"id" : "1", "email" : "alice@example.com"}, {"id" : "2", "email" : "bob@example.com"}]}`,
+		)
+
+		user, err := FindByEmail(driver, email)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it finds the user by scanning", func(t *testing.T) {
+			if user == nil || user.ID != "2" {
+				t.Errorf("Expected user 2")
+			}
+		})
+	})
+
+	t.Run("when the API fails", func(t *testing.T) {
+		driver := &reader{}
+
+		user, err := FindByEmail(driver, email)
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if user != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+
+		t.Run("the error is not a NotFoundError", func(t *testing.T) {
+			if _, ok := err.(*NotFoundError); ok || err == nil {
+				t.Errorf("Expected the API error, got %v", err)
+			}
+		})
+	})
+
+	t.Run("when no user has the email", func(t *testing.T) {
+		driver := &reader{}
+		driver.set("users", filtered, `{"users" : []}`)
+		driver.set("users", unfiltered, `{"users" : [{"id" : "1", "email" : "alice@example.com"}]}`)
+
+		user, err := FindByEmail(driver, email)
+
+		t.Run("the entity is nil", func(t *testing.T) {
+			if user != nil {
+				t.Errorf("Expected a nil entity")
+			}
+		})
+
+		t.Run("the error is a NotFoundError", func(t *testing.T) {
+			if _, ok := err.(*NotFoundError); !ok {
+				t.Errorf("Expected a *NotFoundError, got %T", err)
+			}
+		})
+	})
+}
+
+func TestSearch(t *testing.T) {
+	params := url.Values{}
+	params.Set("page", "1")
+	params.Set("per_page", "100")
+
+	everyone := `{"users" : [
+		{"id" : "1", "name" : "Robert Paulson", "email" : "bob@example.com"},
+		{"id" : "2", "name" : "Tyler Durden", "email" : "tyler@example.com"},
+		{"id" : "3", "name" : "Marla Singer", "email" : "marla@example.com"},
+		{"id" : "4", "name" : "John Smith", "email" : "john@example.com"},
+		{"id" : "5", "name" : "Jane Doe", "email" : "smith@x.com"}
+	]}`
+
+	t.Run("when the query matches a name", func(t *testing.T) {
+		driver := &reader{}
+		driver.set("users", params, everyone)
+
+		results, err := Search(driver, "durden TYLER")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it finds the user regardless of case and word order", func(t *testing.T) {
+			if len(results) != 1 || results[0].ID != "2" {
+				t.Errorf("Expected only user 2")
+			}
+		})
+	})
+
+	t.Run("when the query matches names and email addresses", func(t *testing.T) {
+		driver := &reader{}
+		driver.set("users", params, everyone)
+
+		results, err := Search(driver, "smith")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it finds the users that match by either", func(t *testing.T) {
+			if len(results) != 2 || results[0].ID != "4" || results[1].ID != "5" {
+				t.Errorf("Expected users 4 and 5")
+			}
+		})
+	})
+
+	t.Run("when the query matches an email address", func(t *testing.T) {
+		driver := &reader{}
+		driver.set("users", params, everyone)
+
+		results, _ := Search(driver, "example.com")
+
+		t.Run("it finds every user with a matching address", func(t *testing.T) {
+			if len(results) != 4 {
+				t.Errorf("Expected 4 results, got %d", len(results))
+			}
+		})
+	})
+
+	t.Run("when the API fails", func(t *testing.T) {
+		driver := &reader{}
+
+		results, err := Search(driver, "durden")
+
+		t.Run("there are no results", func(t *testing.T) {
+			if results != nil {
+				t.Errorf("Expected no results")
+			}
+		})
+
+		t.Run("the error is not a NotFoundError", func(t *testing.T) {
+			if _, ok := err.(*NotFoundError); ok || err == nil {
+				t.Errorf("Expected the API error, got %v", err)
+			}
+		})
+	})
+
+	t.Run("when nothing matches", func(t *testing.T) {
+		driver := &reader{}
+		driver.set("users", params, everyone)
+
+		results, err := Search(driver, "project mayhem")
+
+		t.Run("there are no results", func(t *testing.T) {
+			if results != nil {
+				t.Errorf("Expected no results")
+			}
+		})
+
+		t.Run("the error is a NotFoundError", func(t *testing.T) {
+			if _, ok := err.(*NotFoundError); !ok {
+				t.Errorf("Expected a *NotFoundError, got %T", err)
+			}
+		})
+	})
+}
@@ -0,0 +1,46 @@
+// Package volumes provides the data structures and functions for modeling
+// the Volumes endpoint on the Engine Yard API. Volumes are the block storage
+// devices attached to servers.
+package volumes
+
+import (
+	"github.com/ess/maury/timestamp"
+)
+
+// Entity is a flat data structure that maps to an upstream Volume
+type Entity struct {
+	ID string `json:"id,omitempty"`
+
+	// Volume Details
+	Device        string `json:"device,omitempty"`
+	Encrypted     bool   `json:"encrypted,omitempty"`
+	IOPS          int    `json:"iops,omitempty"`
+	Mount         string `json:"mount,omitempty"`
+	Name          string `json:"name,omitempty"`
+	ProvisionedID string `json:"provisioned_id,omitempty"`
+	Size          int    `json:"size,omitempty"`
+	Type          string `json:"type,omitempty"`
+
+	// Relation URLs
+	Server    string `json:"server,omitempty"`
+	Snapshots string `json:"snapshots,omitempty"`
+
+	// Timestamps
+	CreatedAt timestamp.Time `json:"created_at,omitempty"`
+	DeletedAt timestamp.Time `json:"deleted_at,omitempty"`
+	UpdatedAt timestamp.Time `json:"updated_at,omitempty"`
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
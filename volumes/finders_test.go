@@ -0,0 +1,127 @@
+package volumes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/environments"
+	"github.com/ess/maury/maurytest"
+	"github.com/ess/maury/servers"
+)
+
+func TestForServer(t *testing.T) {
+	path := "servers/1/volumes"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"volumes" : [{"id" : "1", "mount" : "/data"}, {"id" : "2", "mount" : "/db"}]}`)
+
+		result, err := ForServer(driver, &servers.Entity{ID: "1"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the volumes attached to the server", func(t *testing.T) {
+			if len(result) != 2 {
+				t.Errorf("Expected 2 volumes")
+			}
+		})
+	})
+}
+
+func TestForEnvironment(t *testing.T) {
+	path := "environments/2/volumes"
+
+	t.Run("when the call succeeds", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, nil, `{"volumes" : [{"id" : "3"}]}`)
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the volumes in the environment", func(t *testing.T) {
+			if len(result) != 1 || result[0].ID != "3" {
+				t.Errorf("Expected only volume 3")
+			}
+		})
+	})
+
+	t.Run("when there are several pages", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", path, maurytest.Page(1, nil), maurytest.Collection("volumes", 1, 100))
+		driver.Respond("GET", path, maurytest.Page(2, nil), maurytest.Collection("volumes", 101, 102))
+
+		result, err := ForEnvironment(driver, &environments.Entity{ID: "2"}, nil)
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it returns the volumes from every page", func(t *testing.T) {
+			if len(result) != 102 {
+				t.Errorf("Expected 102 volumes, got %d", len(result))
+			}
+		})
+	})
+}
+
+func TestFind(t *testing.T) {
+	t.Run("when the volume exists", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "volumes/1", nil, `{"volume" : {
+			"id" : "1",
+			"size" : 100,
+			"iops" : 3000,
+			"type" : "io1",
+			"device" : "/dev/xvdz",
+			"mount" : "/db",
+			"encrypted" : true,
+			"server" : "https://api.engineyard.com/servers/1"
+		}}`)
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it is populated", func(t *testing.T) {
+			if result == nil {
+				t.Fatalf("Expected a volume")
+			}
+
+			if result.Size != 100 || result.IOPS != 3000 || result.Type != "io1" {
+				t.Errorf("Unexpected volume details %+v", result)
+			}
+
+			if result.Device != "/dev/xvdz" || result.Mount != "/db" || !result.Encrypted {
+				t.Errorf("Unexpected volume attachment %+v", result)
+			}
+		})
+	})
+
+	t.Run("when the API has an error", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Fail("GET", "volumes/1", nil, errors.New("nope"))
+
+		result, err := Find(driver, "1")
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil || result != nil {
+				t.Errorf("Expected an error and no entity")
+			}
+		})
+	})
+}
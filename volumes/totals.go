@@ -0,0 +1,59 @@
+package volumes
+
+import (
+	"github.com/ess/maury/accounts"
+)
+
+// Totals summarises the storage used by a set of volumes. Sizes are in
+// gigabytes.
+type Totals struct {
+	Count      int
+	Encrypted  int
+	IOPS       int
+	Size       int
+	SizeByType map[string]int
+}
+
+// Summarize adds up the storage used by the given volumes
+func Summarize(volumes []*Entity) *Totals {
+	totals := &Totals{SizeByType: make(map[string]int)}
+
+	for _, volume := range volumes {
+		totals.Count++
+		totals.IOPS += volume.IOPS
+		totals.Size += volume.Size
+		totals.SizeByType[volume.Type] += volume.Size
+
+		if volume.Encrypted {
+			totals.Encrypted++
+		}
+	}
+
+	return totals
+}
+
+// TotalsForAccount summarises the storage used by all of the volumes in the
+// given account. If the volumes can't be listed, a non-nil error is returned
+// rather than totals that leave some out.
+func TotalsForAccount(driver Reader, account *accounts.Entity) (*Totals, error) {
+	volumes, err := ForAccount(driver, account, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return Summarize(volumes), nil
+}
+
+// Copyright 2018 Dennis Walters
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
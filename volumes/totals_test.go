@@ -0,0 +1,66 @@
+package volumes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ess/maury/accounts"
+	"github.com/ess/maury/maurytest"
+)
+
+func TestTotalsForAccount(t *testing.T) {
+	t.Run("when the volumes can be listed", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "accounts/1/volumes", nil, `{"volumes" : [
+			{"id" : "1", "size" : 100, "iops" : 3000, "type" : "io1", "encrypted" : true},
+			{"id" : "2", "size" : 25, "type" : "gp2"},
+			{"id" : "3", "size" : 50, "type" : "gp2", "encrypted" : true}
+		]}`)
+
+		totals, err := TotalsForAccount(driver, &accounts.Entity{ID: "1"})
+
+		t.Run("it has no error", func(t *testing.T) {
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+		})
+
+		t.Run("it counts the volumes", func(t *testing.T) {
+			if totals.Count != 3 || totals.Encrypted != 2 {
+				t.Errorf("Expected 3 volumes with 2 encrypted, got %d and %d", totals.Count, totals.Encrypted)
+			}
+		})
+
+		t.Run("it adds up the storage", func(t *testing.T) {
+			if totals.Size != 175 || totals.IOPS != 3000 {
+				t.Errorf("Expected 175GB and 3000 IOPS, got %d and %d", totals.Size, totals.IOPS)
+			}
+		})
+
+		t.Run("it breaks the storage down by type", func(t *testing.T) {
+			if totals.SizeByType["io1"] != 100 || totals.SizeByType["gp2"] != 75 {
+				t.Errorf("Unexpected breakdown %v", totals.SizeByType)
+			}
+		})
+	})
+
+	t.Run("when the volumes can't be listed", func(t *testing.T) {
+		driver := maurytest.NewDriver()
+		driver.Respond("GET", "accounts/1/volumes", maurytest.Page(1, nil), maurytest.Collection("volumes", 1, 100))
+		driver.Fail("GET", "accounts/1/volumes", maurytest.Page(2, nil), errors.New("nope"))
+
+		totals, err := TotalsForAccount(driver, &accounts.Entity{ID: "1"})
+
+		t.Run("it has an error", func(t *testing.T) {
+			if err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+
+		t.Run("there are no partial totals", func(t *testing.T) {
+			if totals != nil {
+				t.Errorf("Expected no totals, got %+v", totals)
+			}
+		})
+	})
+}